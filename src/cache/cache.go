@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/settings"
+)
+
+const (
+	MEMORY_DRIVER = "memory"
+	REDIS_DRIVER  = "redis"
+)
+
+const DEFAULT_CACHE_SIZE = 1000
+
+// Cache stores raw values with a TTL. Values that are not found or
+// expired are reported as missing
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+	Delete(keys ...string)
+}
+
+var settingsData = settings.GetSettings()
+
+func NewCache() Cache {
+	if settingsData.CACHE_DRIVER == REDIS_DRIVER {
+		return newRedisCache(settingsData.REDIS_URL)
+	}
+	size, err := strconv.Atoi(settingsData.CACHE_SIZE)
+	if err != nil || size <= 0 {
+		size = DEFAULT_CACHE_SIZE
+	}
+	return newMemoryCache(size)
+}
@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// In-process LRU cache, the least recently used entry is evicted
+// when the capacity is reached
+type MemoryCache struct {
+	lock     sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*memoryEntry)
+	if time.Now().After(entry.expires) {
+		m.remove(element)
+		return nil, false
+	}
+	m.order.MoveToFront(element)
+	return entry.value, true
+}
+
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	expires := time.Now().Add(ttl)
+	if element, ok := m.entries[key]; ok {
+		entry := element.Value.(*memoryEntry)
+		entry.value = value
+		entry.expires = expires
+		m.order.MoveToFront(element)
+		return
+	}
+	m.entries[key] = m.order.PushFront(&memoryEntry{
+		key:     key,
+		value:   value,
+		expires: expires,
+	})
+	for m.order.Len() > m.capacity {
+		m.remove(m.order.Back())
+	}
+}
+
+func (m *MemoryCache) Delete(keys ...string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, key := range keys {
+		if element, ok := m.entries[key]; ok {
+			m.remove(element)
+		}
+	}
+}
+
+func (m *MemoryCache) remove(element *list.Element) {
+	m.order.Remove(element)
+	delete(m.entries, element.Value.(*memoryEntry).key)
+}
+
+func newMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
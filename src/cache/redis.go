@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Shared cache between instances of the service. Redis errors are
+// treated as cache misses, the cache is never the source of truth
+type RedisCache struct {
+	client *redis.Client
+}
+
+func (r *RedisCache) Get(key string) ([]byte, bool) {
+	value, err := r.client.Get(context.Background(), key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func (r *RedisCache) Set(key string, value []byte, ttl time.Duration) {
+	r.client.Set(context.Background(), key, value, ttl)
+}
+
+func (r *RedisCache) Delete(keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	r.client.Del(context.Background(), keys...)
+}
+
+func newRedisCache(url string) *RedisCache {
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		panic(err)
+	}
+	return &RedisCache{
+		client: redis.NewClient(options),
+	}
+}
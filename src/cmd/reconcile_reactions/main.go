@@ -0,0 +1,16 @@
+package main
+
+import (
+	"log"
+
+	"github.com/CPU-commits/Intranet_BNews/src/services"
+)
+
+// Recompute the reaction counters stored on news from the reactions collection
+func main() {
+	reactionsService := services.NewReactionsService()
+	if err := reactionsService.ReconcileReactions(); err != nil {
+		log.Fatalf("Error reconciling reactions: %v", err)
+	}
+	log.Println("Reactions reconciled")
+}
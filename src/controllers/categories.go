@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/CPU-commits/Intranet_BNews/src/forms"
+	"github.com/CPU-commits/Intranet_BNews/src/res"
+	"github.com/CPU-commits/Intranet_BNews/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+// Services
+var categoriesService = services.NewCategoriesService()
+
+type CategoriesController struct{}
+
+// GetCategories godoc
+// @Summary Get categories
+// @Description Get active categories of news
+// @Tags news
+// @Accept json
+// @Produce json
+// @Success 200 {object} res.Response{body=smaps.CategoriesMap}
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /get_categories [get]
+func (categories *CategoriesController) GetCategories(c *gin.Context) {
+	categoriesData, err := categoriesService.GetCategories()
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	// Response
+	response := make(map[string]interface{})
+	response["categories"] = categoriesData
+	c.JSON(200, res.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// NewCategory godoc
+// @Summary New category
+// @Description Create a category of news
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param data body forms.CategoryDTO true "Category"
+// @Success 201 {object} res.Response{body=smaps.CategoryIDMap}
+// @Failure 400 {object} res.Response{} "Bad body param"
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 409 {object} res.Response{} "La categoría ya existe"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /new_category [post]
+func (categories *CategoriesController) NewCategory(c *gin.Context) {
+	var data forms.CategoryDTO
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, res.Response{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	idCategory, err := categoriesService.NewCategory(data)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	c.JSON(201, res.Response{
+		Success: true,
+		Data: gin.H{
+			"_id": idCategory,
+		},
+	})
+}
+
+// UpdateCategory godoc
+// @Summary Update category
+// @Description Rename a category of news
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idCategory path string true "MongoID"
+// @Param data body forms.CategoryDTO true "Category"
+// @Success 200 {object} res.Response{} ""
+// @Failure 400 {object} res.Response{} "Bad path || body param"
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 404 {object} res.Response{} "Categoría no encontrada"
+// @Failure 409 {object} res.Response{} "La categoría ya existe"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /update_category/{idCategory} [put]
+func (categories *CategoriesController) UpdateCategory(c *gin.Context) {
+	var data forms.CategoryDTO
+	idCategory := c.Param("idCategory")
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, res.Response{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	err := categoriesService.UpdateCategory(data, idCategory)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	c.JSON(200, res.Response{
+		Success: true,
+	})
+}
+
+// DeleteCategory godoc
+// @Summary Delete category
+// @Description Delete a category, its news keep it
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idCategory path string true "MongoID"
+// @Success 200 {object} res.Response{} ""
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 404 {object} res.Response{} "Categoría no encontrada"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /delete_category/{idCategory} [delete]
+func (categories *CategoriesController) DeleteCategory(c *gin.Context) {
+	idCategory := c.Param("idCategory")
+
+	err := categoriesService.DeleteCategory(idCategory)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	c.JSON(200, res.Response{
+		Success: true,
+	})
+}
+
+// GetTags godoc
+// @Summary Get tags
+// @Description Tag cloud, most used tags of the visible news
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param limit query integer false "Default 30, max 100"
+// @Param type query string false "Default global -> Values: global || student"
+// @Success 200 {object} res.Response{body=smaps.TagsMap}
+// @Failure 400 {object} res.Response{} "Bad query param"
+// @Failure 403 {object} res.Response{} "No tienes acceso a estas noticias"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /get_tags [get]
+func (categories *CategoriesController) GetTags(c *gin.Context) {
+	claims, _ := services.NewClaimsFromContext(c)
+	limit := c.DefaultQuery("limit", "30")
+	newsType := c.DefaultQuery("type", "global")
+
+	tags, err := categoriesService.GetTags(limit, newsType, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	// Response
+	response := make(map[string]interface{})
+	response["tags"] = tags
+	c.JSON(200, res.Response{
+		Success: true,
+		Data:    response,
+	})
+}
@@ -0,0 +1,236 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/CPU-commits/Intranet_BNews/src/forms"
+	"github.com/CPU-commits/Intranet_BNews/src/res"
+	"github.com/CPU-commits/Intranet_BNews/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+// Services
+var commentsService = services.NewCommentsService()
+
+type CommentsController struct{}
+
+// GetComments godoc
+// @Summary Get comments
+// @Description Get comments of a news, or the replies of a comment
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idNews path string true "MongoID"
+// @Param parent query string false "MongoID of the comment to get its replies"
+// @Param skip query integer false "Default 0"
+// @Param limit query integer false "Default 20, max 100"
+// @Success 200 {object} res.Response{body=smaps.CommentsMap}
+// @Failure 400 {object} res.Response{} "Bad query param"
+// @Failure 404 {object} res.Response{} "Noticia no encontrada"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /get_comments/{idNews} [get]
+func (comments *CommentsController) GetComments(c *gin.Context) {
+	idNews := c.Param("idNews")
+	claims, _ := services.NewClaimsFromContext(c)
+	parent := c.Query("parent")
+	skip := c.DefaultQuery("skip", "0")
+	limit := c.DefaultQuery("limit", "20")
+	// Get
+	commentsData, total, err := commentsService.GetComments(
+		idNews,
+		parent,
+		skip,
+		limit,
+		claims,
+	)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	// Response
+	response := make(map[string]interface{})
+	response["comments"] = commentsData
+	response["total"] = total
+	c.JSON(200, res.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// NewComment godoc
+// @Summary New comment
+// @Description Comment a news or reply a comment
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idNews path string true "MongoID"
+// @Param data body forms.CommentDTO true "Comment"
+// @Success 201 {object} res.Response{body=smaps.CommentIDMap}
+// @Failure 400 {object} res.Response{} "Bad path || body param"
+// @Failure 403 {object} res.Response{} "Los comentarios de esta noticia están desactivados"
+// @Failure 404 {object} res.Response{} "Noticia no encontrada"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /new_comment/{idNews} [post]
+func (comments *CommentsController) NewComment(c *gin.Context) {
+	var data forms.CommentDTO
+	idNews := c.Param("idNews")
+	claims, _ := services.NewClaimsFromContext(c)
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, res.Response{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	idComment, err := commentsService.NewComment(data, idNews, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	c.JSON(201, res.Response{
+		Success: true,
+		Data: gin.H{
+			"_id": idComment,
+		},
+	})
+}
+
+// UpdateComment godoc
+// @Summary Update comment
+// @Description Update own comment
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idComment path string true "MongoID"
+// @Param data body forms.UpdateCommentDTO true "Comment"
+// @Success 200 {object} res.Response{} ""
+// @Failure 400 {object} res.Response{} "Bad path || body param"
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 404 {object} res.Response{} "Comentario no encontrado"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /update_comment/{idComment} [put]
+func (comments *CommentsController) UpdateComment(c *gin.Context) {
+	var data forms.UpdateCommentDTO
+	idComment := c.Param("idComment")
+	claims, _ := services.NewClaimsFromContext(c)
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, res.Response{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	err := commentsService.UpdateComment(data, idComment, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	c.JSON(200, res.Response{
+		Success: true,
+	})
+}
+
+// DeleteComment godoc
+// @Summary Delete comment
+// @Description Delete own comment
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idComment path string true "MongoID"
+// @Success 200 {object} res.Response{} ""
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 404 {object} res.Response{} "Comentario no encontrado"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /delete_comment/{idComment} [delete]
+func (comments *CommentsController) DeleteComment(c *gin.Context) {
+	idComment := c.Param("idComment")
+	claims, _ := services.NewClaimsFromContext(c)
+
+	err := commentsService.DeleteComment(idComment, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	c.JSON(200, res.Response{
+		Success: true,
+	})
+}
+
+// HideComment godoc
+// @Summary Hide comment
+// @Description Toggle the visibility of a comment, for those who can manage the news.
+// @Description Teachers only moderate the comments of their news
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idComment path string true "MongoID"
+// @Success 200 {object} res.Response{body=smaps.HiddenMap} ""
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 404 {object} res.Response{} "Comentario no encontrado"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /hide_comment/{idComment} [post]
+func (comments *CommentsController) HideComment(c *gin.Context) {
+	idComment := c.Param("idComment")
+	claims, _ := services.NewClaimsFromContext(c)
+
+	hidden, err := commentsService.HideComment(idComment, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	c.JSON(200, res.Response{
+		Success: true,
+		Data: gin.H{
+			"hidden": hidden,
+		},
+	})
+}
+
+// ToggleComments godoc
+// @Summary Toggle comments
+// @Description Enable or disable the comments of a news, teachers only of their news
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idNews path string true "MongoID"
+// @Success 200 {object} res.Response{body=smaps.CommentsDisabledMap} ""
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 404 {object} res.Response{} "Noticia no encontrada"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /toggle_comments/{idNews} [post]
+func (comments *CommentsController) ToggleComments(c *gin.Context) {
+	idNews := c.Param("idNews")
+	claims, _ := services.NewClaimsFromContext(c)
+
+	disabled, err := commentsService.ToggleComments(idNews, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	c.JSON(200, res.Response{
+		Success: true,
+		Data: gin.H{
+			"comments_disabled": disabled,
+		},
+	})
+}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"strings"
+
+	"github.com/CPU-commits/Intranet_BNews/src/res"
+	"github.com/CPU-commits/Intranet_BNews/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+// Services
+var filesService = services.NewFilesService()
+
+type FilesController struct{}
+
+// GetFile godoc
+// @Summary Get file
+// @Description Get a file of the local storage by its presigned URL
+// @Tags files
+// @Produce octet-stream
+// @Param key path string true "Key of the file"
+// @Param expires query string true "Expiry of the URL"
+// @Param signature query string true "Signature of the URL"
+// @Success 200 {file} binary
+// @Failure 403 {object} res.Response{} "El enlace del archivo no es válido o expiró"
+// @Failure 404 {object} res.Response{} "No existe el archivo"
+// @Router /files/{key} [get]
+func (files *FilesController) GetFile(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	file, info, err := filesService.GetSignedFile(
+		key,
+		c.Query("expires"),
+		c.Query("signature"),
+	)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(200, info.Size, info.ContentType, file, map[string]string{
+		"Cache-Control": "private, max-age=300",
+	})
+}
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"github.com/CPU-commits/Intranet_BNews/src/res"
+	"github.com/CPU-commits/Intranet_BNews/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+// Services
+var mediaService = services.NewMediaService()
+
+type MediaController struct{}
+
+// GetMedia godoc
+// @Summary Get media library
+// @Description Images uploaded to the news that can be reused, the last uploaded first.
+// @Description Teachers and student directives only get their own uploads
+// @Tags media
+// @Accept json
+// @Produce json
+// @Param skip query integer false "Default 0"
+// @Param limit query integer false "Default 20, max 100"
+// @Success 200 {object} res.Response{body=smaps.MediaMap}
+// @Failure 400 {object} res.Response{} "Bad query param"
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 503 {object} res.Response{} "Service Unavailable - NATS || DB Service Unavailable"
+// @Router /get_media [get]
+func (media *MediaController) GetMedia(c *gin.Context) {
+	claims, _ := services.NewClaimsFromContext(c)
+	skip := c.DefaultQuery("skip", "0")
+	limit := c.DefaultQuery("limit", "20")
+	// Get
+	mediaData, total, err := mediaService.GetMedia(skip, limit, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	// Response
+	response := make(map[string]interface{})
+	response["media"] = mediaData
+	response["total"] = total
+	c.JSON(200, res.Response{
+		Success: true,
+		Data:    response,
+	})
+}
@@ -1,7 +1,10 @@
 package controllers
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/CPU-commits/Intranet_BNews/src/forms"
 	"github.com/CPU-commits/Intranet_BNews/src/res"
@@ -11,12 +14,15 @@ import (
 
 // Services
 var newsService = services.NewNewsService()
-var likesService = services.NewLikesService()
+var reactionsService = services.NewReactionsService()
+var readsService = services.NewReadsService()
+var acknowledgementsService = services.NewAcknowledgementsService()
 
 type NewsController struct{}
 
 func init() {
 	UploadNews()
+	StartJobs()
 }
 
 // Nats
@@ -24,6 +30,15 @@ func UploadNews() {
 	newsService.UploadNews()
 }
 
+// Jobs
+func StartJobs() {
+	newsService.PublishScheduledNews()
+	newsService.ArchiveExpiredNews()
+	newsService.PurgeTrash()
+	uploadsService.SweepExpiredUploads()
+	uploadsService.ProcessUploads()
+}
+
 // API
 // GetSingleNews godoc
 // @Summary Get a single news
@@ -36,17 +51,27 @@ func UploadNews() {
 // @Failure 404 {object} res.Response{} "No pudimos encontrar la noticia..."
 // @Failure 410 {object} res.Response{} "Esta noticia ya no está disponible"
 // @Failure 401 {object} res.Response{} "No tienes acceso a esta noticia"
+// @Param If-None-Match header string false "ETag of the cached news"
+// @Param If-Modified-Since header string false "Last-Modified of the cached news"
+// @Header 200 {string} ETag "Version of the news and its validator"
+// @Header 200 {string} Last-Modified "Last change of the news, its counters or the state of the user"
+// @Success 304 "Not Modified"
 // @Router /get_single_news/{slug} [get]
 func (n *NewsController) GetSingleNews(c *gin.Context) {
 	slug := c.Param("slug")
 	claims, _ := services.NewClaimsFromContext(c)
 	// Find
-	news, err := newsService.GetSingleNews(slug, claims)
+	news, validator, err := newsService.GetSingleNews(slug, claims)
 	if err != nil {
 		c.AbortWithStatusJSON(err.StatusCode, res.Response{
 			Message: err.Err.Error(),
 			Success: false,
 		})
+		return
+	}
+	// Conditional request
+	if writeValidator(c, validator) {
+		return
 	}
 	// Response
 	response := make(map[string]interface{})
@@ -66,8 +91,16 @@ func (n *NewsController) GetSingleNews(c *gin.Context) {
 // @Param skip query integer false "Default 0"
 // @Param total query bool false "Defaul false"
 // @Paraam limit query integer false "Default 15"
+// @Param after query string false "Cursor of next_cursor, ignores skip"
 // @Param type query string false "Default global -> Values: global || student"
+// @Param category query string false "MongoID of the category"
+// @Param tag query string false "Tag"
+// @Param If-None-Match header string false "ETag of the cached page"
+// @Param If-Modified-Since header string false "Last-Modified of the cached page"
 // @Success 200 {object} res.Response{body=smaps.NewsMap}
+// @Header 200 {string} ETag "Validator of the page"
+// @Header 200 {string} Last-Modified "Last change of the news of the page, their counters or the state of the user"
+// @Success 304 "Not Modified"
 // @Failure 503 {object} res.Response{} "StatusServiceUnavailable"
 // @Failure 400 {object} res.Response{} "Bad query param"
 // @Router /get_news [get]
@@ -76,12 +109,72 @@ func (n *NewsController) GetNews(c *gin.Context) {
 	skip := c.DefaultQuery("skip", "0")
 	total := c.DefaultQuery("total", "false")
 	limit := c.DefaultQuery("limit", "15")
+	after := c.Query("after")
 	newsType := c.DefaultQuery("type", "global")
+	category := c.Query("category")
+	tag := c.Query("tag")
 	// Get
-	news, totalData, err := newsService.GetNews(
+	page, err := newsService.GetNews(
 		skip,
 		total == "true",
 		limit,
+		after,
+		newsType,
+		category,
+		tag,
+		claims,
+	)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	// Conditional request
+	if writeValidator(c, page.Validator) {
+		return
+	}
+	// Response
+	response := make(map[string]interface{})
+	response["news"] = page.News
+	response["total"] = page.Total
+	response["next_cursor"] = page.NextCursor
+	c.JSON(200, res.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetArchivedNews godoc
+// @Summary Get archived news
+// @Description Get expired news, filtered by month and year of upload
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param skip query integer false "Default 0"
+// @Param limit query integer false "Default 15, max 100"
+// @Param month query integer false "1-12, Default all the year"
+// @Param year query integer false "Default current year"
+// @Param type query string false "Default global -> Values: global || student"
+// @Success 200 {object} res.Response{body=smaps.NewsMap}
+// @Failure 503 {object} res.Response{} "StatusServiceUnavailable"
+// @Failure 400 {object} res.Response{} "Bad query param"
+// @Failure 401 {object} res.Response{} "No tienes acceso a estas noticias"
+// @Router /get_archived_news [get]
+func (n *NewsController) GetArchivedNews(c *gin.Context) {
+	claims, _ := services.NewClaimsFromContext(c)
+	skip := c.DefaultQuery("skip", "0")
+	limit := c.DefaultQuery("limit", "15")
+	month := c.Query("month")
+	year := c.DefaultQuery("year", strconv.Itoa(time.Now().Year()))
+	newsType := c.DefaultQuery("type", "global")
+	// Get
+	news, err := newsService.GetArchivedNews(
+		skip,
+		limit,
+		month,
+		year,
 		newsType,
 		claims,
 	)
@@ -95,7 +188,42 @@ func (n *NewsController) GetNews(c *gin.Context) {
 	// Response
 	response := make(map[string]interface{})
 	response["news"] = news
-	response["total"] = totalData
+	c.JSON(200, res.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// SearchNews godoc
+// @Summary Search news
+// @Description Full-text search over title, headline and body ordered by relevance
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param q query string true "Search"
+// @Param skip query integer false "Default 0"
+// @Param limit query integer false "Default 15, max 100"
+// @Success 200 {object} res.Response{body=smaps.NewsMap}
+// @Failure 503 {object} res.Response{} "StatusServiceUnavailable"
+// @Failure 400 {object} res.Response{} "Bad query param"
+// @Router /search [get]
+func (n *NewsController) SearchNews(c *gin.Context) {
+	claims, _ := services.NewClaimsFromContext(c)
+	q := c.Query("q")
+	skip := c.DefaultQuery("skip", "0")
+	limit := c.DefaultQuery("limit", "15")
+	// Search
+	news, err := newsService.SearchNews(q, skip, limit, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	// Response
+	response := make(map[string]interface{})
+	response["news"] = news
 	c.JSON(200, res.Response{
 		Success: true,
 		Data:    response,
@@ -104,7 +232,7 @@ func (n *NewsController) GetNews(c *gin.Context) {
 
 // NewNews godoc
 // @Summary New news
-// @Description New news
+// @Description New news, with an uploaded image or one of the media library
 // @Tags news
 // @Accept mpfd
 // @Produce json
@@ -113,6 +241,11 @@ func (n *NewsController) GetNews(c *gin.Context) {
 // @Failure 400 {object} res.Response{} "Bad body"
 // @Failure 400 {object} res.Response{} "El titulo de la noticia ya está en uso"
 // @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 403 {object} res.Response{} "Solo puedes publicar noticias a tus cursos"
+// @Failure 404 {object} res.Response{} "No existe la imagen"
+// @Failure 413 {object} res.Response{} "La imagen supera el tamaño máximo de 20MB"
+// @Failure 415 {object} res.Response{} "Tipo de archivo no permitido"
+// @Failure 422 {object} res.Response{} "La imagen no es válida || supera las dimensiones máximas"
 // @Failure 503 {object} res.Response{} "Service Unavailable - NATS || DB Service Unavailable"
 // @Router /new_news [post]
 func (news *NewsController) NewNews(c *gin.Context) {
@@ -126,9 +259,9 @@ func (news *NewsController) NewNews(c *gin.Context) {
 		})
 		return
 	}
-	// Get file from form
+	// Get file from form, not needed with an image of the library
 	file, err := c.FormFile("img")
-	if err != nil {
+	if err != nil && err != http.ErrMissingFile {
 		c.AbortWithStatusJSON(http.StatusBadRequest, res.Response{
 			Success: false,
 			Message: "Ha ocurrido un error tratando de leer el archivo",
@@ -153,7 +286,7 @@ func (news *NewsController) NewNews(c *gin.Context) {
 
 // LikeNews godoc
 // @Summary Like news
-// @Description Toggle Like news
+// @Description Toggle Like news, alias of the like reaction
 // @Tags news
 // @Accept json
 // @Produce json
@@ -167,7 +300,270 @@ func (news *NewsController) LikeNews(c *gin.Context) {
 	idNews := c.Param("idNews")
 	claims, _ := services.NewClaimsFromContext(c)
 	// Get news
-	err := likesService.LikeNews(idNews, claims)
+	err := reactionsService.LikeNews(idNews, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	c.JSON(200, res.Response{
+		Success: true,
+	})
+}
+
+// MarkAsRead godoc
+// @Summary Mark news as read
+// @Description Mark news as read, reading a single news also marks it
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idNews path string true "MongoID"
+// @Success 200 {object} res.Response{} ""
+// @Failure 400 {object} res.Response{} "Bad path param"
+// @Failure 404 {object} res.Response{} "Noticia no encontrada"
+// @Failure 503 {object} res.Response{} "Service Unavailable - NATS || DB Service Unavailable"
+// @Router /read_news/{idNews} [post]
+func (news *NewsController) MarkAsRead(c *gin.Context) {
+	idNews := c.Param("idNews")
+	claims, _ := services.NewClaimsFromContext(c)
+
+	err := readsService.MarkAsRead(idNews, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	c.JSON(200, res.Response{
+		Success: true,
+	})
+}
+
+// MarkAllAsRead godoc
+// @Summary Mark all news as read
+// @Description Mark every published news visible to the user as read
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param type query string false "Default global -> Values: global || student"
+// @Success 200 {object} res.Response{} ""
+// @Failure 401 {object} res.Response{} "No tienes acceso a estas noticias"
+// @Failure 503 {object} res.Response{} "Service Unavailable - NATS || DB Service Unavailable"
+// @Router /read_all_news [post]
+func (news *NewsController) MarkAllAsRead(c *gin.Context) {
+	claims, _ := services.NewClaimsFromContext(c)
+	newsType := c.DefaultQuery("type", "global")
+
+	err := readsService.MarkAllAsRead(newsType, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	c.JSON(200, res.Response{
+		Success: true,
+	})
+}
+
+// GetUnreadCount godoc
+// @Summary Get unread count
+// @Description Count of published news visible to the user that they have not read
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param type query string false "Default global -> Values: global || student"
+// @Success 200 {object} res.Response{body=smaps.UnreadCountMap}
+// @Failure 401 {object} res.Response{} "No tienes acceso a estas noticias"
+// @Failure 503 {object} res.Response{} "Service Unavailable - NATS || DB Service Unavailable"
+// @Router /unread_count [get]
+func (news *NewsController) GetUnreadCount(c *gin.Context) {
+	claims, _ := services.NewClaimsFromContext(c)
+	newsType := c.DefaultQuery("type", "global")
+
+	unread, err := readsService.GetUnreadCount(newsType, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	// Response
+	response := make(map[string]interface{})
+	response["unread"] = unread
+	c.JSON(200, res.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// AcknowledgeNews godoc
+// @Summary Acknowledge news
+// @Description Confirm the reading of a news that requires acknowledgement
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idNews path string true "MongoID"
+// @Success 200 {object} res.Response{} ""
+// @Failure 400 {object} res.Response{} "Esta noticia no requiere confirmación de lectura"
+// @Failure 404 {object} res.Response{} "Noticia no encontrada"
+// @Failure 503 {object} res.Response{} "Service Unavailable - NATS || DB Service Unavailable"
+// @Router /acknowledge_news/{idNews} [post]
+func (news *NewsController) AcknowledgeNews(c *gin.Context) {
+	idNews := c.Param("idNews")
+	claims, _ := services.NewClaimsFromContext(c)
+
+	err := acknowledgementsService.AcknowledgeNews(idNews, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	c.JSON(200, res.Response{
+		Success: true,
+	})
+}
+
+// GetAcknowledgements godoc
+// @Summary Get acknowledgements
+// @Description Who acknowledged a news and when, and who is still pending.
+// @Description The users of courses and levels are requested to the users service. If it does not answer,
+// @Description pending_partial is true and pending only has the users that read the news without confirming it.
+// @Description Both lists are paginated, the CSV has the whole report
+// @Tags news
+// @Accept json
+// @Produce json,text/csv
+// @Param idNews path string true "MongoID"
+// @Param format query string false "Default json -> Values: json || csv"
+// @Param skip query integer false "Default 0"
+// @Param limit query integer false "Default 50, max 100"
+// @Success 200 {object} res.Response{body=smaps.AcknowledgementsMap}
+// @Failure 400 {object} res.Response{} "Bad query param"
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 404 {object} res.Response{} "Noticia no encontrada"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable || No se pudieron obtener los usuarios de la audiencia"
+// @Router /get_acknowledgements/{idNews} [get]
+func (news *NewsController) GetAcknowledgements(c *gin.Context) {
+	idNews := c.Param("idNews")
+	claims, _ := services.NewClaimsFromContext(c)
+	format := c.DefaultQuery("format", "json")
+	skip := c.DefaultQuery("skip", "0")
+	limit := c.DefaultQuery("limit", "50")
+
+	if format == "csv" {
+		export, err := acknowledgementsService.ExportAcknowledgements(idNews, claims)
+		if err != nil {
+			c.AbortWithStatusJSON(err.StatusCode, res.Response{
+				Success: false,
+				Message: err.Err.Error(),
+			})
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=confirmaciones_%s.csv", idNews))
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Status(200)
+		export.WriteCSV(c.Writer)
+		return
+	}
+	report, err := acknowledgementsService.GetAcknowledgements(idNews, skip, limit, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	// Response
+	response := make(map[string]interface{})
+	response["acknowledged"] = report.Acknowledged
+	response["total_acknowledged"] = report.TotalAcknowledged
+	response["pending"] = report.Pending
+	response["total_pending"] = report.TotalPending
+	c.JSON(200, res.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetReactions godoc
+// @Summary Get reactions
+// @Description Get allowed reactions
+// @Tags news
+// @Accept json
+// @Produce json
+// @Success 200 {object} res.Response{body=smaps.ReactionsMap}
+// @Router /get_reactions [get]
+func (news *NewsController) GetReactions(c *gin.Context) {
+	c.JSON(200, res.Response{
+		Success: true,
+		Data: gin.H{
+			"reactions": reactionsService.GetReactions(),
+		},
+	})
+}
+
+// ReactNews godoc
+// @Summary React news
+// @Description Set the reaction of the user to a news, replacing the previous one
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idNews path string true "MongoID"
+// @Param data body forms.ReactionDTO true "Reaction"
+// @Success 200 {object} res.Response{} ""
+// @Failure 400 {object} res.Response{} "Bad path || body param || Reacción no permitida"
+// @Failure 404 {object} res.Response{} "Noticia no encontrada"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /react_news/{idNews} [post]
+func (news *NewsController) ReactNews(c *gin.Context) {
+	var data forms.ReactionDTO
+	idNews := c.Param("idNews")
+	claims, _ := services.NewClaimsFromContext(c)
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, res.Response{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	err := reactionsService.SetReaction(idNews, data.Reaction, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	c.JSON(200, res.Response{
+		Success: true,
+	})
+}
+
+// ClearReaction godoc
+// @Summary Clear reaction
+// @Description Remove the reaction of the user to a news
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idNews path string true "MongoID"
+// @Success 200 {object} res.Response{} ""
+// @Failure 400 {object} res.Response{} "Bad path param"
+// @Failure 404 {object} res.Response{} "Noticia no encontrada"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /react_news/{idNews} [delete]
+func (news *NewsController) ClearReaction(c *gin.Context) {
+	idNews := c.Param("idNews")
+	claims, _ := services.NewClaimsFromContext(c)
+
+	err := reactionsService.ClearReaction(idNews, claims)
 	if err != nil {
 		c.AbortWithStatusJSON(err.StatusCode, res.Response{
 			Success: false,
@@ -188,10 +584,17 @@ func (news *NewsController) LikeNews(c *gin.Context) {
 // @Produce json
 // @Param idNews path string true "MongoID"
 // @Param data body forms.UpdateNewsDTO true "Update"
+// @Param If-Match header string false "ETag of the edited version"
 // @Success 200 {object} res.Response{body=smaps.SingleNewsMap} ""
+// @Header 200 {string} ETag "Version of the news"
+// @Failure 412 {object} res.Response{body=smaps.SingleNewsMap} "La noticia fue modificada por otro usuario || If-Match no admite ETags débiles"
 // @Failure 401 {object} res.Response{} "Unauthorized"
 // @Failure 400 {object} res.Response{} "Bad path || body param"
-// @Failure 404 {object} res.Response{} "Noticia no encontrada"
+// @Failure 404 {object} res.Response{} "Noticia no encontrada || No existe la imagen"
+// @Failure 403 {object} res.Response{} "Solo puedes publicar noticias a tus cursos"
+// @Failure 413 {object} res.Response{} "La imagen supera el tamaño máximo de 20MB"
+// @Failure 415 {object} res.Response{} "Tipo de archivo no permitido"
+// @Failure 422 {object} res.Response{} "La imagen no es válida || supera las dimensiones máximas"
 // @Router /update_news/{idNews} [put]
 func (news *NewsController) UpdateNews(c *gin.Context) {
 	// Data
@@ -207,16 +610,23 @@ func (news *NewsController) UpdateNews(c *gin.Context) {
 		return
 	}
 	// Update
-	newsData, errRes := newsService.UpdateNews(data, id, claims)
+	newsData, errRes := newsService.UpdateNews(data, id, c.GetHeader("If-Match"), claims)
 	if errRes != nil {
+		// Conflict, current version of the news
+		response := make(map[string]interface{})
+		if newsData != nil {
+			response["news"] = newsData
+		}
 		c.AbortWithStatusJSON(errRes.StatusCode, res.Response{
 			Success: false,
 			Message: errRes.Err.Error(),
+			Data:    response,
 		})
 		return
 	}
 
 	// Response
+	c.Header("ETag", services.NewsETag(newsData.Version))
 	response := make(map[string]interface{})
 	response["news"] = newsData
 
@@ -226,22 +636,165 @@ func (news *NewsController) UpdateNews(c *gin.Context) {
 	})
 }
 
-// @Summary Update news
-// @Description Update news
+// ScheduleNews godoc
+// @Summary Schedule news
+// @Description Schedule a draft to be published at a date
 // @Tags news
 // @Accept json
 // @Produce json
 // @Param idNews path string true "MongoID"
+// @Param data body forms.ScheduleNewsDTO true "Schedule"
 // @Success 200 {object} res.Response{} ""
 // @Failure 401 {object} res.Response{} "Unauthorized"
 // @Failure 400 {object} res.Response{} "Bad path || body param"
 // @Failure 404 {object} res.Response{} "Noticia no encontrada"
+// @Failure 409 {object} res.Response{} "La noticia ya fue publicada"
+// @Router /schedule_news/{idNews} [post]
+func (news *NewsController) ScheduleNews(c *gin.Context) {
+	var data forms.ScheduleNewsDTO
+	id := c.Param("idNews")
+	claims, _ := services.NewClaimsFromContext(c)
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, res.Response{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	// Schedule
+	err := newsService.ScheduleNews(data, id, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	c.JSON(200, res.Response{
+		Success: true,
+	})
+}
+
+// PublishNews godoc
+// @Summary Publish news
+// @Description Publish a draft or scheduled news now
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idNews path string true "MongoID"
+// @Success 200 {object} res.Response{} ""
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 404 {object} res.Response{} "Noticia no encontrada"
+// @Failure 409 {object} res.Response{} "La noticia ya fue publicada"
+// @Failure 503 {object} res.Response{} "Service Unavailable - NATS || DB Service Unavailable"
+// @Router /publish_news/{idNews} [post]
+func (news *NewsController) PublishNews(c *gin.Context) {
+	id := c.Param("idNews")
+	claims, _ := services.NewClaimsFromContext(c)
+	// Publish
+	err := newsService.PublishNews(id, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	c.JSON(200, res.Response{
+		Success: true,
+	})
+}
+
+// DeleteNews godoc
+// @Summary Delete news
+// @Description Move news to the trash, it is purged after the retention period
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idNews path string true "MongoID"
+// @Param If-Match header string false "ETag of the deleted version"
+// @Success 200 {object} res.Response{} ""
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 400 {object} res.Response{} "Bad path || body param"
+// @Failure 404 {object} res.Response{} "Noticia no encontrada"
+// @Failure 412 {object} res.Response{body=smaps.SingleNewsMap} "La noticia fue modificada por otro usuario || If-Match no admite ETags débiles"
 // @Router /delete_news/{idNews} [delete]
 func (news *NewsController) DeleteNews(c *gin.Context) {
 	id := c.Param("idNews")
 	claims, _ := services.NewClaimsFromContext(c)
 	// Delete
-	err := newsService.DeleteNews(id, claims)
+	current, err := newsService.DeleteNews(id, c.GetHeader("If-Match"), claims)
+	if err != nil {
+		// Conflict, current version of the news
+		response := make(map[string]interface{})
+		if current != nil {
+			response["news"] = current
+		}
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+			Data:    response,
+		})
+		return
+	}
+	c.JSON(200, res.Response{
+		Success: true,
+	})
+}
+
+// GetTrash godoc
+// @Summary Get trash
+// @Description Deleted news that can be restored, the last deleted first
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param skip query integer false "Default 0"
+// @Param limit query integer false "Default 15, max 100"
+// @Success 200 {object} res.Response{body=smaps.NewsMap}
+// @Failure 400 {object} res.Response{} "Bad query param"
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /get_trash [get]
+func (n *NewsController) GetTrash(c *gin.Context) {
+	claims, _ := services.NewClaimsFromContext(c)
+	skip := c.DefaultQuery("skip", "0")
+	limit := c.DefaultQuery("limit", "15")
+	// Get
+	news, err := newsService.GetTrash(skip, limit, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	// Response
+	response := make(map[string]interface{})
+	response["news"] = news
+	c.JSON(200, res.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// RestoreNews godoc
+// @Summary Restore news
+// @Description Restore news from the trash
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idNews path string true "MongoID"
+// @Success 200 {object} res.Response{} ""
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 404 {object} res.Response{} "La noticia no está en la papelera"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /restore_news/{idNews} [post]
+func (news *NewsController) RestoreNews(c *gin.Context) {
+	id := c.Param("idNews")
+	claims, _ := services.NewClaimsFromContext(c)
+	// Restore
+	err := newsService.RestoreNews(id, claims)
 	if err != nil {
 		c.AbortWithStatusJSON(err.StatusCode, res.Response{
 			Success: false,
@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"github.com/CPU-commits/Intranet_BNews/src/res"
+	"github.com/CPU-commits/Intranet_BNews/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+// Services
+var revisionsService = services.NewRevisionsService()
+
+type RevisionsController struct{}
+
+// GetRevisions godoc
+// @Summary Get revisions
+// @Description Revision history of a news, the last first
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idNews path string true "MongoID"
+// @Success 200 {object} res.Response{body=smaps.RevisionsMap}
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 404 {object} res.Response{} "Noticia no encontrada"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /get_revisions/{idNews} [get]
+func (revisions *RevisionsController) GetRevisions(c *gin.Context) {
+	idNews := c.Param("idNews")
+	claims, _ := services.NewClaimsFromContext(c)
+
+	revisionsData, err := revisionsService.GetRevisions(idNews, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	// Response
+	response := make(map[string]interface{})
+	response["revisions"] = revisionsData
+	c.JSON(200, res.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetRevision godoc
+// @Summary Get revision
+// @Description Content of a news in a revision
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idRevision path string true "MongoID"
+// @Success 200 {object} res.Response{body=smaps.RevisionMap}
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 404 {object} res.Response{} "Revisión no encontrada"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /get_revision/{idRevision} [get]
+func (revisions *RevisionsController) GetRevision(c *gin.Context) {
+	idRevision := c.Param("idRevision")
+	claims, _ := services.NewClaimsFromContext(c)
+
+	revision, err := revisionsService.GetRevision(idRevision, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	// Response
+	response := make(map[string]interface{})
+	response["revision"] = revision
+	c.JSON(200, res.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// DiffRevisions godoc
+// @Summary Diff revisions
+// @Description Fields that changed from a revision to another of the same news
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param from query string true "MongoID of the revision"
+// @Param to query string true "MongoID of the revision"
+// @Success 200 {object} res.Response{body=smaps.RevisionDiffMap}
+// @Failure 400 {object} res.Response{} "Las revisiones no son de la misma noticia"
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 404 {object} res.Response{} "Revisión no encontrada"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /diff_revisions [get]
+func (revisions *RevisionsController) DiffRevisions(c *gin.Context) {
+	claims, _ := services.NewClaimsFromContext(c)
+	from := c.Query("from")
+	to := c.Query("to")
+
+	diff, err := revisionsService.DiffRevisions(from, to, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	// Response
+	response := make(map[string]interface{})
+	response["diff"] = diff
+	c.JSON(200, res.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// RestoreRevision godoc
+// @Summary Restore revision
+// @Description Restore the content of a revision, it is kept as a new revision
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idRevision path string true "MongoID"
+// @Param If-Match header string false "ETag of the edited version"
+// @Success 200 {object} res.Response{} ""
+// @Header 200 {string} ETag "Version of the news"
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 404 {object} res.Response{} "Revisión no encontrada"
+// @Failure 412 {object} res.Response{body=smaps.SingleNewsMap} "La noticia fue modificada por otro usuario || If-Match no admite ETags débiles"
+// @Failure 503 {object} res.Response{} "Service Unavailable - DB Service Unavailable"
+// @Router /restore_revision/{idRevision} [post]
+func (revisions *RevisionsController) RestoreRevision(c *gin.Context) {
+	idRevision := c.Param("idRevision")
+	claims, _ := services.NewClaimsFromContext(c)
+
+	newsData, err := revisionsService.RestoreRevision(idRevision, c.GetHeader("If-Match"), claims)
+	if err != nil {
+		// Conflict, current version of the news
+		response := make(map[string]interface{})
+		if newsData != nil {
+			response["news"] = newsData
+		}
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+			Data:    response,
+		})
+		return
+	}
+	c.Header("ETag", services.NewsETag(newsData.Version))
+	c.JSON(200, res.Response{
+		Success: true,
+	})
+}
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/CPU-commits/Intranet_BNews/src/forms"
+	"github.com/CPU-commits/Intranet_BNews/src/res"
+	"github.com/CPU-commits/Intranet_BNews/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+// Services
+var uploadsService = services.NewUploadsService()
+
+type UploadsController struct{}
+
+// GetUploadURL godoc
+// @Summary Get upload URL
+// @Description Get a presigned POST to upload an image directly to the storage.
+// @Description Send the fields and the file as multipart/form-data to the url, then confirm the key
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param data body forms.UploadURLDTO true "File to upload"
+// @Success 200 {object} res.Response{body=smaps.UploadURLMap}
+// @Failure 400 {object} res.Response{} "Bad body param"
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 413 {object} res.Response{} "El archivo supera el tamaño máximo de 20MB"
+// @Failure 415 {object} res.Response{} "Tipo de archivo no permitido"
+// @Failure 503 {object} res.Response{} "Service Unavailable"
+// @Router /upload_url [post]
+func (uploads *UploadsController) GetUploadURL(c *gin.Context) {
+	var data forms.UploadURLDTO
+	claims, _ := services.NewClaimsFromContext(c)
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, res.Response{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	upload, err := uploadsService.GetUploadURL(data, claims)
+	if err != nil {
+		c.AbortWithStatusJSON(err.StatusCode, res.Response{
+			Success: false,
+			Message: err.Err.Error(),
+		})
+		return
+	}
+	// Response
+	response := make(map[string]interface{})
+	response["upload"] = upload
+	c.JSON(200, res.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// ConfirmUpload godoc
+// @Summary Confirm upload
+// @Description Confirm an image uploaded directly to the storage as image of the news.
+// @Description The image is processed in the background and set to the news once it is stored
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param idNews path string true "MongoID"
+// @Param data body forms.ConfirmUploadDTO true "Uploaded file"
+// @Param If-Match header string false "ETag of the edited version"
+// @Success 202 {object} res.Response{body=smaps.SingleNewsMap} "Current news, before the image is set"
+// @Header 202 {string} ETag "Version of the news"
+// @Failure 400 {object} res.Response{} "Bad path || body param"
+// @Failure 401 {object} res.Response{} "Unauthorized"
+// @Failure 404 {object} res.Response{} "El archivo no fue subido"
+// @Failure 412 {object} res.Response{body=smaps.SingleNewsMap} "La noticia fue modificada por otro usuario || If-Match no admite ETags débiles"
+// @Failure 422 {object} res.Response{} "El archivo subido no es válido"
+// @Failure 503 {object} res.Response{} "Service Unavailable"
+// @Router /confirm_upload/{idNews} [post]
+func (uploads *UploadsController) ConfirmUpload(c *gin.Context) {
+	var data forms.ConfirmUploadDTO
+	id := c.Param("idNews")
+	claims, _ := services.NewClaimsFromContext(c)
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, res.Response{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	newsData, errRes := uploadsService.ConfirmUpload(data, id, c.GetHeader("If-Match"), claims)
+	if errRes != nil {
+		// Conflict, current version of the news
+		response := make(map[string]interface{})
+		if newsData != nil {
+			response["news"] = newsData
+		}
+		c.AbortWithStatusJSON(errRes.StatusCode, res.Response{
+			Success: false,
+			Message: errRes.Err.Error(),
+			Data:    response,
+		})
+		return
+	}
+	// Response
+	c.Header("ETag", services.NewsETag(newsData.Version))
+	response := make(map[string]interface{})
+	response["news"] = newsData
+	c.JSON(http.StatusAccepted, res.Response{
+		Success: true,
+		Data:    response,
+	})
+}
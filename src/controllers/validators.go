@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/CPU-commits/Intranet_BNews/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+// Responses depend on the user, they are revalidated on every request.
+// Returns true if the response was not modified and 304 was sent
+func writeValidator(c *gin.Context, validator *services.Validator) bool {
+	c.Header("Cache-Control", "private, no-cache")
+	c.Header("Vary", "Authorization")
+	if validator == nil {
+		return false
+	}
+	c.Header("ETag", validator.ETag)
+	if !validator.LastModified.IsZero() {
+		c.Header("Last-Modified", validator.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if validator.NotModified(c.GetHeader("If-None-Match"), c.GetHeader("If-Modified-Since")) {
+		c.AbortWithStatus(http.StatusNotModified)
+		return true
+	}
+	return false
+}
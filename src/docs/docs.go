@@ -30,9 +30,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/delete_news/{idNews}": {
-            "delete": {
-                "description": "Update news",
+        "/acknowledge_news/{idNews}": {
+            "post": {
+                "description": "Confirm the reading of a news that requires acknowledgement",
                 "consumes": [
                     "application/json"
                 ],
@@ -42,7 +42,7 @@ const docTemplate = `{
                 "tags": [
                     "news"
                 ],
-                "summary": "Update news",
+                "summary": "Acknowledge news",
                 "parameters": [
                     {
                         "type": "string",
@@ -60,19 +60,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad path || body param",
+                        "description": "Esta noticia no requiere confirmación de lectura",
                         "schema": {
                             "$ref": "#/definitions/res.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Noticia no encontrada",
                         "schema": {
                             "$ref": "#/definitions/res.Response"
                         }
                     },
-                    "404": {
-                        "description": "Noticia no encontrada",
+                    "503": {
+                        "description": "Service Unavailable - NATS || DB Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/res.Response"
                         }
@@ -80,9 +80,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/get_news": {
-            "get": {
-                "description": "Get news",
+        "/confirm_upload/{idNews}": {
+            "post": {
+                "description": "Confirm an image uploaded directly to the storage as image of the news.\nThe image is processed in the background and set to the news once it is stored",
                 "consumes": [
                     "application/json"
                 ],
@@ -92,30 +92,34 @@ const docTemplate = `{
                 "tags": [
                     "news"
                 ],
-                "summary": "Get news",
+                "summary": "Confirm upload",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Default 0",
-                        "name": "skip",
-                        "in": "query"
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idNews",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "boolean",
-                        "description": "Defaul false",
-                        "name": "total",
-                        "in": "query"
+                        "description": "Uploaded file",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.ConfirmUploadDTO"
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "Default global -\u003e Values: global || student",
-                        "name": "type",
-                        "in": "query"
+                        "description": "ETag of the edited version",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Current news, before the image is set",
                         "schema": {
                             "allOf": [
                                 {
@@ -125,21 +129,63 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/smaps.NewsMap"
+                                            "$ref": "#/definitions/smaps.SingleNewsMap"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the news"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Bad query param",
+                        "description": "Bad path || body param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "El archivo no fue subido",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "La noticia fue modificada por otro usuario || If-Match no admite ETags débiles",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.SingleNewsMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "El archivo subido no es válido",
                         "schema": {
                             "$ref": "#/definitions/res.Response"
                         }
                     },
                     "503": {
-                        "description": "StatusServiceUnavailable",
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/res.Response"
                         }
@@ -147,9 +193,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/get_single_news/{slug}": {
-            "get": {
-                "description": "Get a single news",
+        "/delete_category/{idCategory}": {
+            "delete": {
+                "description": "Delete a category, its news keep it",
                 "consumes": [
                     "application/json"
                 ],
@@ -159,12 +205,12 @@ const docTemplate = `{
                 "tags": [
                     "news"
                 ],
-                "summary": "Get a single news",
+                "summary": "Delete category",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "News slug",
-                        "name": "slug",
+                        "description": "MongoID",
+                        "name": "idCategory",
                         "in": "path",
                         "required": true
                     }
@@ -173,35 +219,23 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/res.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "body": {
-                                            "$ref": "#/definitions/smaps.SingleNewsMap"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/res.Response"
                         }
                     },
                     "401": {
-                        "description": "No tienes acceso a esta noticia",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/res.Response"
                         }
                     },
                     "404": {
-                        "description": "No pudimos encontrar la noticia...",
+                        "description": "Categoría no encontrada",
                         "schema": {
                             "$ref": "#/definitions/res.Response"
                         }
                     },
-                    "410": {
-                        "description": "Esta noticia ya no está disponible",
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/res.Response"
                         }
@@ -209,9 +243,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/like_news/{idNews}": {
-            "post": {
-                "description": "Toggle Like news",
+        "/delete_comment/{idComment}": {
+            "delete": {
+                "description": "Delete own comment",
                 "consumes": [
                     "application/json"
                 ],
@@ -221,12 +255,12 @@ const docTemplate = `{
                 "tags": [
                     "news"
                 ],
-                "summary": "Like news",
+                "summary": "Delete comment",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "MongoID",
-                        "name": "idNews",
+                        "name": "idComment",
                         "in": "path",
                         "required": true
                     }
@@ -238,20 +272,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/res.Response"
                         }
                     },
-                    "400": {
-                        "description": "Bad path param",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/res.Response"
                         }
                     },
                     "404": {
-                        "description": "Noticia no encontrada",
+                        "description": "Comentario no encontrado",
                         "schema": {
                             "$ref": "#/definitions/res.Response"
                         }
                     },
                     "503": {
-                        "description": "Service Unavailable - NATS || DB Service Unavailable",
+                        "description": "Service Unavailable - DB Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/res.Response"
                         }
@@ -259,11 +293,11 @@ const docTemplate = `{
                 }
             }
         },
-        "/new_news": {
-            "post": {
-                "description": "New news",
+        "/delete_news/{idNews}": {
+            "delete": {
+                "description": "Move news to the trash, it is purged after the retention period",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -271,59 +305,31 @@ const docTemplate = `{
                 "tags": [
                     "news"
                 ],
-                "summary": "New news",
+                "summary": "Delete news",
                 "parameters": [
                     {
                         "type": "string",
-                        "name": "body",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "maxLength": 500,
-                        "minLength": 3,
-                        "type": "string",
-                        "name": "headline",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "format": "binary",
-                        "name": "img",
-                        "in": "formData",
+                        "description": "MongoID",
+                        "name": "idNews",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "maxLength": 100,
-                        "minLength": 3,
                         "type": "string",
-                        "name": "title",
-                        "in": "formData",
-                        "required": true
+                        "description": "ETag of the deleted version",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/res.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "body": {
-                                            "$ref": "#/definitions/smaps.SingleNewsMap"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/res.Response"
                         }
                     },
                     "400": {
-                        "description": "El titulo de la noticia ya está en uso",
+                        "description": "Bad path || body param",
                         "schema": {
                             "$ref": "#/definitions/res.Response"
                         }
@@ -334,18 +340,36 @@ const docTemplate = `{
                             "$ref": "#/definitions/res.Response"
                         }
                     },
-                    "503": {
-                        "description": "Service Unavailable - NATS || DB Service Unavailable",
+                    "404": {
+                        "description": "Noticia no encontrada",
                         "schema": {
                             "$ref": "#/definitions/res.Response"
                         }
+                    },
+                    "412": {
+                        "description": "La noticia fue modificada por otro usuario || If-Match no admite ETags débiles",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.SingleNewsMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     }
                 }
             }
         },
-        "/update_news/{idNews}": {
-            "put": {
-                "description": "Update news",
+        "/diff_revisions": {
+            "get": {
+                "description": "Fields that changed from a revision to another of the same news",
                 "consumes": [
                     "application/json"
                 ],
@@ -355,23 +379,21 @@ const docTemplate = `{
                 "tags": [
                     "news"
                 ],
-                "summary": "Update news",
+                "summary": "Diff revisions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "MongoID",
-                        "name": "idNews",
-                        "in": "path",
+                        "description": "MongoID of the revision",
+                        "name": "from",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Update",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/forms.UpdateNewsDTO"
-                        }
+                        "type": "string",
+                        "description": "MongoID of the revision",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -386,7 +408,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/smaps.SingleNewsMap"
+                                            "$ref": "#/definitions/smaps.RevisionDiffMap"
                                         }
                                     }
                                 }
@@ -394,7 +416,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad path || body param",
+                        "description": "Las revisiones no son de la misma noticia",
                         "schema": {
                             "$ref": "#/definitions/res.Response"
                         }
@@ -406,146 +428,3137 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Noticia no encontrada",
+                        "description": "Revisión no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/res.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "forms.UpdateNewsDTO": {
-            "type": "object",
-            "properties": {
-                "body": {
-                    "type": "string"
-                },
-                "headline": {
-                    "type": "string",
-                    "maxLength": 500,
-                    "minLength": 3
-                },
-                "img": {
-                    "type": "string",
-                    "format": "binary"
-                },
-                "title": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
-                }
-            }
         },
-        "models.User": {
-            "type": "object",
-            "properties": {
-                "_id": {
-                    "type": "string",
-                    "x-omitempty": true,
-                    "example": "638660ca141aa4ee9faf07e8"
-                },
-                "first_lastname": {
-                    "type": "string",
-                    "x-omitempty": true,
-                    "example": "Rojas"
-                },
-                "name": {
-                    "type": "string",
-                    "x-omitempty": true,
-                    "example": "Karen"
-                },
+        "/files/{key}": {
+            "get": {
+                "description": "Get a file of the local storage by its presigned URL",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Get file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key of the file",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expiry of the URL",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signature of the URL",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "El enlace del archivo no es válido o expiró",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "No existe el archivo",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/get_acknowledgements/{idNews}": {
+            "get": {
+                "description": "Who acknowledged a news and when, and who is still pending.\nThe users of courses and levels are requested to the users service. If it does not answer,\npending_partial is true and pending only has the users that read the news without confirming it.\nBoth lists are paginated, the CSV has the whole report",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get acknowledgements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idNews",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Default json -\u003e Values: json || csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Default 0",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Default 50, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.AcknowledgementsMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad query param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Noticia no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable || No se pudieron obtener los usuarios de la audiencia",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/get_archived_news": {
+            "get": {
+                "description": "Get expired news, filtered by month and year of upload",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get archived news",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Default 0",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Default 15, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1-12, Default all the year",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Default current year",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Default global -\u003e Values: global || student",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.NewsMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad query param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "No tienes acceso a estas noticias",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "StatusServiceUnavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/get_categories": {
+            "get": {
+                "description": "Get active categories of news",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get categories",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.CategoriesMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/get_comments/{idNews}": {
+            "get": {
+                "description": "Get comments of a news, or the replies of a comment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get comments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idNews",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "MongoID of the comment to get its replies",
+                        "name": "parent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Default 0",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Default 20, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.CommentsMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad query param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Noticia no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/get_media": {
+            "get": {
+                "description": "Images uploaded to the news that can be reused, the last uploaded first.\nTeachers and student directives only get their own uploads",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Get media library",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Default 0",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Default 20, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.MediaMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad query param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - NATS || DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/get_news": {
+            "get": {
+                "description": "Get news",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get news",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Default 0",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Defaul false",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of next_cursor, ignores skip",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Default global -\u003e Values: global || student",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "MongoID of the category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached page",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of the cached page",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.NewsMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Validator of the page"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Last change of the news of the page, their counters or the state of the user"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad query param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "StatusServiceUnavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/get_reactions": {
+            "get": {
+                "description": "Get allowed reactions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get reactions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.ReactionsMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/get_revision/{idRevision}": {
+            "get": {
+                "description": "Content of a news in a revision",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idRevision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.RevisionMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Revisión no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/get_revisions/{idNews}": {
+            "get": {
+                "description": "Revision history of a news, the last first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idNews",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.RevisionsMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Noticia no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/get_single_news/{slug}": {
+            "get": {
+                "description": "Get a single news",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get a single news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached news",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of the cached news",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.SingleNewsMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the news and its validator"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Last change of the news, its counters or the state of the user"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "401": {
+                        "description": "No tienes acceso a esta noticia",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "No pudimos encontrar la noticia...",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "410": {
+                        "description": "Esta noticia ya no está disponible",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/get_tags": {
+            "get": {
+                "description": "Tag cloud, most used tags of the visible news",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Default 30, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Default global -\u003e Values: global || student",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.TagsMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad query param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "No tienes acceso a estas noticias",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/get_trash": {
+            "get": {
+                "description": "Deleted news that can be restored, the last deleted first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Default 0",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Default 15, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.NewsMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad query param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/hide_comment/{idComment}": {
+            "post": {
+                "description": "Toggle the visibility of a comment, for those who can manage the news.\nTeachers only moderate the comments of their news",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Hide comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idComment",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.HiddenMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Comentario no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/like_news/{idNews}": {
+            "post": {
+                "description": "Toggle Like news, alias of the like reaction",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Like news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idNews",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad path param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Noticia no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - NATS || DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/new_category": {
+            "post": {
+                "description": "Create a category of news",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "New category",
+                "parameters": [
+                    {
+                        "description": "Category",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.CategoryDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.CategoryIDMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad body param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "La categoría ya existe",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/new_comment/{idNews}": {
+            "post": {
+                "description": "Comment a news or reply a comment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "New comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idNews",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.CommentDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.CommentIDMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad path || body param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Los comentarios de esta noticia están desactivados",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Noticia no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/new_news": {
+            "post": {
+                "description": "New news, with an uploaded image or one of the media library",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "New news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "body",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "638660ca141aa4ee9faf07e8",
+                        "name": "category",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "name": "courses",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "name": "draft",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2022-10-21T20:10:23Z",
+                        "name": "expiresAt",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 500,
+                        "minLength": 3,
+                        "type": "string",
+                        "name": "headline",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "638660ca141aa4ee9faf07e8",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "format": "binary",
+                        "name": "img",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "name": "levels",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2022-09-21T20:10:23Z",
+                        "name": "publishAt",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "name": "requiresAck",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "name": "roles",
+                        "in": "formData"
+                    },
+                    {
+                        "maxItems": 10,
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "name": "tags",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 100,
+                        "minLength": 3,
+                        "type": "string",
+                        "name": "title",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.SingleNewsMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "El titulo de la noticia ya está en uso",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Solo puedes publicar noticias a tus cursos",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "No existe la imagen",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "La imagen supera el tamaño máximo de 20MB",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Tipo de archivo no permitido",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "La imagen no es válida || supera las dimensiones máximas",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - NATS || DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/publish_news/{idNews}": {
+            "post": {
+                "description": "Publish a draft or scheduled news now",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Publish news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idNews",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Noticia no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "La noticia ya fue publicada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - NATS || DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/react_news/{idNews}": {
+            "post": {
+                "description": "Set the reaction of the user to a news, replacing the previous one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "React news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idNews",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reaction",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.ReactionDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad path || body param || Reacción no permitida",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Noticia no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the reaction of the user to a news",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Clear reaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idNews",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad path param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Noticia no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/read_all_news": {
+            "post": {
+                "description": "Mark every published news visible to the user as read",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Mark all news as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Default global -\u003e Values: global || student",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "No tienes acceso a estas noticias",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - NATS || DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/read_news/{idNews}": {
+            "post": {
+                "description": "Mark news as read, reading a single news also marks it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Mark news as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idNews",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad path param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Noticia no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - NATS || DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restore_news/{idNews}": {
+            "post": {
+                "description": "Restore news from the trash",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Restore news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idNews",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "La noticia no está en la papelera",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restore_revision/{idRevision}": {
+            "post": {
+                "description": "Restore the content of a revision, it is kept as a new revision",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Restore revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idRevision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the edited version",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the news"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Revisión no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "La noticia fue modificada por otro usuario || If-Match no admite ETags débiles",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.SingleNewsMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule_news/{idNews}": {
+            "post": {
+                "description": "Schedule a draft to be published at a date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Schedule news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idNews",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.ScheduleNewsDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad path || body param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Noticia no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "La noticia ya fue publicada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "Full-text search over title, headline and body ordered by relevance",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Search news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Default 0",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Default 15, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.NewsMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad query param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "StatusServiceUnavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/toggle_comments/{idNews}": {
+            "post": {
+                "description": "Enable or disable the comments of a news, teachers only of their news",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Toggle comments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idNews",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.CommentsDisabledMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Noticia no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/unread_count": {
+            "get": {
+                "description": "Count of published news visible to the user that they have not read",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get unread count",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Default global -\u003e Values: global || student",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.UnreadCountMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "No tienes acceso a estas noticias",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - NATS || DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/update_category/{idCategory}": {
+            "put": {
+                "description": "Rename a category of news",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Update category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idCategory",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.CategoryDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad path || body param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Categoría no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "La categoría ya existe",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/update_comment/{idComment}": {
+            "put": {
+                "description": "Update own comment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Update comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idComment",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.UpdateCommentDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad path || body param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Comentario no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable - DB Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/update_news/{idNews}": {
+            "put": {
+                "description": "Update news",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Update news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MongoID",
+                        "name": "idNews",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.UpdateNewsDTO"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the edited version",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.SingleNewsMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the news"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad path || body param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Solo puedes publicar noticias a tus cursos",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Noticia no encontrada || No existe la imagen",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "La noticia fue modificada por otro usuario || If-Match no admite ETags débiles",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.SingleNewsMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "413": {
+                        "description": "La imagen supera el tamaño máximo de 20MB",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Tipo de archivo no permitido",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "La imagen no es válida || supera las dimensiones máximas",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/upload_url": {
+            "post": {
+                "description": "Get a presigned POST to upload an image directly to the storage.\nSend the fields and the file as multipart/form-data to the url, then confirm the key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get upload URL",
+                "parameters": [
+                    {
+                        "description": "File to upload",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.UploadURLDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/res.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/smaps.UploadURLMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad body param",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "El archivo supera el tamaño máximo de 20MB",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Tipo de archivo no permitido",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/res.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "forms.CategoryDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3,
+                    "example": "Deportes"
+                }
+            }
+        },
+        "forms.CommentDTO": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "minLength": 1
+                },
+                "parent": {
+                    "type": "string",
+                    "example": "638660ca141aa4ee9faf07e8"
+                }
+            }
+        },
+        "forms.ConfirmUploadDTO": {
+            "type": "object",
+            "required": [
+                "key"
+            ],
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "example": "news/1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed.jpg"
+                }
+            }
+        },
+        "forms.ReactionDTO": {
+            "type": "object",
+            "required": [
+                "reaction"
+            ],
+            "properties": {
+                "reaction": {
+                    "type": "string",
+                    "example": "like"
+                }
+            }
+        },
+        "forms.ScheduleNewsDTO": {
+            "type": "object",
+            "required": [
+                "publish_at"
+            ],
+            "properties": {
+                "publish_at": {
+                    "type": "string",
+                    "example": "2022-09-21T20:10:23Z"
+                }
+            }
+        },
+        "forms.UpdateCommentDTO": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "minLength": 1
+                }
+            }
+        },
+        "forms.UpdateNewsDTO": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "638660ca141aa4ee9faf07e8"
+                },
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expiresAt": {
+                    "type": "string",
+                    "example": "2022-10-21T20:10:23Z"
+                },
+                "headline": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 3
+                },
+                "image": {
+                    "type": "string",
+                    "example": "638660ca141aa4ee9faf07e8"
+                },
+                "img": {
+                    "type": "string",
+                    "format": "binary"
+                },
+                "levels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "requiresAck": {
+                    "type": "boolean"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                }
+            }
+        },
+        "forms.UploadURLDTO": {
+            "type": "object",
+            "required": [
+                "content_type",
+                "size"
+            ],
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "enum": [
+                        "image/jpeg",
+                        "image/png",
+                        "image/webp",
+                        "image/gif"
+                    ],
+                    "example": "image/jpeg"
+                },
+                "size": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 204800
+                }
+            }
+        },
+        "models.Audience": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "levels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.Category": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.User": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "x-omitempty": true,
+                    "example": "638660ca141aa4ee9faf07e8"
+                },
+                "first_lastname": {
+                    "type": "string",
+                    "x-omitempty": true,
+                    "example": "Rojas"
+                },
+                "name": {
+                    "type": "string",
+                    "x-omitempty": true,
+                    "example": "Karen"
+                },
                 "second_lastname": {
                     "type": "string",
                     "x-omitempty": true,
-                    "example": "Valdes"
+                    "example": "Valdes"
+                }
+            }
+        },
+        "res.Response": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "services.AcknowledgementResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2022-09-21T20:10:23.309+00:00"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                }
+            }
+        },
+        "services.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "638660ca141aa4ee9faf07e8"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Deportes"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "deportes"
+                }
+            }
+        },
+        "services.CommentResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "638660ca141aa4ee9faf07e8"
+                },
+                "author": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "body": {
+                    "type": "string",
+                    "example": "Great!"
+                },
+                "hidden": {
+                    "type": "boolean"
+                },
+                "parent": {
+                    "type": "string",
+                    "x-omitempty": true,
+                    "example": "638660ca141aa4ee9faf07e8"
+                },
+                "replies": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "update_date": {
+                    "type": "string",
+                    "example": "2022-09-21T20:10:23.309+00:00"
+                },
+                "upload_date": {
+                    "type": "string",
+                    "example": "2022-09-21T20:10:23.309+00:00"
+                }
+            }
+        },
+        "services.Image": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "638660ca141aa4ee9faf07e8"
+                },
+                "blurhash": {
+                    "type": "string",
+                    "x-omitempty": true,
+                    "example": "LpDdPVBUwxX9m0WEjte=gJfjfQfj"
+                },
+                "color": {
+                    "type": "string",
+                    "x-omitempty": true,
+                    "example": "#0816c8"
+                },
+                "height": {
+                    "type": "integer",
+                    "x-omitempty": true,
+                    "example": 1080
+                },
+                "key": {
+                    "type": "string",
+                    "example": "$dsK2!1"
+                },
+                "srcset": {
+                    "type": "string",
+                    "example": "https://repository.com/file/$dsK2!1 320w, https://repository.com/file/$dsK2!2 640w"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://repository.com/file/$dsK2!1"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ImageVariant"
+                    }
+                },
+                "width": {
+                    "type": "integer",
+                    "x-omitempty": true,
+                    "example": 1920
+                }
+            }
+        },
+        "services.ImageVariant": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer",
+                    "example": 360
+                },
+                "name": {
+                    "type": "string",
+                    "example": "card"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://repository.com/file/$dsK2!2"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 640
+                }
+            }
+        },
+        "services.MediaResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "638660ca141aa4ee9faf07e8"
+                },
+                "blurhash": {
+                    "type": "string",
+                    "x-omitempty": true,
+                    "example": "LpDdPVBUwxX9m0WEjte=gJfjfQfj"
+                },
+                "color": {
+                    "type": "string",
+                    "x-omitempty": true,
+                    "example": "#0816c8"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2022-09-21T20:10:23.309+00:00"
+                },
+                "height": {
+                    "type": "integer",
+                    "x-omitempty": true,
+                    "example": 1080
+                },
+                "thumbnail": {
+                    "type": "string",
+                    "example": "https://repository.com/file/$dsK2!2"
+                },
+                "uploader": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    ],
+                    "x-omitempty": true
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://repository.com/file/$dsK2!1"
+                },
+                "uses": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "width": {
+                    "type": "integer",
+                    "x-omitempty": true,
+                    "example": 1920
+                }
+            }
+        },
+        "services.NewsResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "638660ca141aa4ee9faf07e8"
+                },
+                "acknowledged": {
+                    "type": "boolean"
+                },
+                "audience": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Audience"
+                        }
+                    ],
+                    "x-omitempty": true
+                },
+                "author": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    ],
+                    "x-omitempty": true
+                },
+                "body": {
+                    "type": "string",
+                    "example": "This is a body..."
+                },
+                "category": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/services.CategoryResponse"
+                        }
+                    ],
+                    "x-omitempty": true
+                },
+                "comments": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "comments_disabled": {
+                    "type": "boolean"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "x-omitempty": true,
+                    "example": "2022-09-21T20:10:23.309+00:00"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "x-omitempty": true,
+                    "example": "2022-10-21T20:10:23.309+00:00"
+                },
+                "headline": {
+                    "type": "string",
+                    "example": "Example..."
+                },
+                "image": {
+                    "$ref": "#/definitions/services.Image"
+                },
+                "like": {
+                    "type": "boolean"
+                },
+                "likes": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "publish_at": {
+                    "type": "string",
+                    "x-omitempty": true,
+                    "example": "2022-09-21T20:10:23.309+00:00"
+                },
+                "reaction": {
+                    "type": "string",
+                    "x-omitempty": true,
+                    "example": "like"
+                },
+                "reactions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "read": {
+                    "type": "boolean"
+                },
+                "requires_ack": {
+                    "type": "boolean"
+                },
+                "score": {
+                    "type": "number",
+                    "x-omitempty": true,
+                    "example": 1.5
+                },
+                "snippet": {
+                    "type": "string",
+                    "x-omitempty": true,
+                    "example": "...la \u003cmark\u003ematrícula\u003c/mark\u003e 2023..."
+                },
+                "state": {
+                    "type": "string",
+                    "example": "published"
+                },
+                "status": {
+                    "type": "boolean"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "matrícula",
+                        "2023"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Title !!"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "global"
+                },
+                "update_date": {
+                    "type": "string",
+                    "example": "2022-09-21T20:10:23.309+00:00"
+                },
+                "upload_date": {
+                    "type": "string",
+                    "example": "2022-09-21T20:10:23.309+00:00"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "title"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "res.Response": {
+        "services.ReactionResponse": {
             "type": "object",
             "properties": {
-                "body": {
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "message": {
-                    "type": "string"
+                "emoji": {
+                    "type": "string",
+                    "example": "👍"
                 },
-                "success": {
-                    "type": "boolean"
+                "name": {
+                    "type": "string",
+                    "example": "like"
                 }
             }
         },
-        "services.Image": {
+        "services.RevisionDiffResponse": {
             "type": "object",
             "properties": {
-                "_id": {
+                "field": {
                     "type": "string",
-                    "example": "638660ca141aa4ee9faf07e8"
+                    "example": "title"
                 },
-                "key": {
+                "from": {
                     "type": "string",
-                    "example": "$dsK2!1"
+                    "example": "Title !!"
                 },
-                "url": {
+                "to": {
                     "type": "string",
-                    "example": "https://repository.com/file/$dsK2!1"
+                    "example": "New title !!"
                 }
             }
         },
-        "services.NewsResponse": {
+        "services.RevisionResponse": {
             "type": "object",
             "properties": {
                 "_id": {
                     "type": "string",
                     "example": "638660ca141aa4ee9faf07e8"
                 },
-                "author": {
-                    "x-omitempty": true,
-                    "$ref": "#/definitions/models.User"
-                },
                 "body": {
                     "type": "string",
+                    "x-omitempty": true,
                     "example": "This is a body..."
                 },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "title",
+                        "body"
+                    ]
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2022-09-21T20:10:23.309+00:00"
+                },
+                "editor": {
+                    "$ref": "#/definitions/models.User"
+                },
                 "headline": {
                     "type": "string",
                     "example": "Example..."
                 },
-                "image": {
-                    "$ref": "#/definitions/services.Image"
-                },
-                "like": {
-                    "type": "boolean"
+                "img": {
+                    "type": "string",
+                    "example": "638660ca141aa4ee9faf07e8"
                 },
-                "likes": {
-                    "type": "integer",
-                    "example": 10
+                "news": {
+                    "type": "string",
+                    "example": "638660ca141aa4ee9faf07e8"
                 },
-                "status": {
-                    "type": "boolean"
+                "restored_from": {
+                    "type": "string",
+                    "x-omitempty": true,
+                    "example": "638660ca141aa4ee9faf07e8"
                 },
                 "title": {
                     "type": "string",
                     "example": "Title !!"
+                }
+            }
+        },
+        "services.TagResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 4
                 },
-                "type": {
+                "tag": {
                     "type": "string",
-                    "example": "global"
-                },
-                "update_date": {
+                    "example": "matrícula"
+                }
+            }
+        },
+        "services.UploadURLResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
                     "type": "string",
                     "example": "2022-09-21T20:10:23.309+00:00"
                 },
-                "upload_date": {
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "key": {
                     "type": "string",
-                    "example": "2022-09-21T20:10:23.309+00:00"
+                    "example": "news/1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed.jpg"
                 },
                 "url": {
                     "type": "string",
-                    "example": "title"
+                    "example": "https://bucket.s3.us-east-1.amazonaws.com"
+                }
+            }
+        },
+        "smaps.AcknowledgementsMap": {
+            "type": "object",
+            "properties": {
+                "acknowledged": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.AcknowledgementResponse"
+                    }
+                },
+                "pending": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.User"
+                    }
+                },
+                "pending_partial": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "total_acknowledged": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "total_pending": {
+                    "type": "integer",
+                    "example": 15
+                }
+            }
+        },
+        "smaps.CategoriesMap": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Category"
+                    }
+                }
+            }
+        },
+        "smaps.CategoryIDMap": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "638660ca141aa4ee9faf07e8"
+                }
+            }
+        },
+        "smaps.CommentIDMap": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "638660ca141aa4ee9faf07e8"
+                }
+            }
+        },
+        "smaps.CommentsDisabledMap": {
+            "type": "object",
+            "properties": {
+                "comments_disabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "smaps.CommentsMap": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.CommentResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 15
+                }
+            }
+        },
+        "smaps.HiddenMap": {
+            "type": "object",
+            "properties": {
+                "hidden": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "smaps.MediaMap": {
+            "type": "object",
+            "properties": {
+                "media": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.MediaResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 15
                 }
             }
         },
@@ -558,12 +3571,57 @@ const docTemplate = `{
                         "$ref": "#/definitions/services.NewsResponse"
                     }
                 },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "MTY2Mzc5MTAyMzMwOV82Mzg2NjBjYTE0MWFhNGVlOWZhZjA3ZTg"
+                },
                 "total": {
                     "type": "integer",
                     "example": 15
                 }
             }
         },
+        "smaps.ReactionsMap": {
+            "type": "object",
+            "properties": {
+                "reactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ReactionResponse"
+                    }
+                }
+            }
+        },
+        "smaps.RevisionDiffMap": {
+            "type": "object",
+            "properties": {
+                "diff": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.RevisionDiffResponse"
+                    }
+                }
+            }
+        },
+        "smaps.RevisionMap": {
+            "type": "object",
+            "properties": {
+                "revision": {
+                    "$ref": "#/definitions/services.RevisionResponse"
+                }
+            }
+        },
+        "smaps.RevisionsMap": {
+            "type": "object",
+            "properties": {
+                "revisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.RevisionResponse"
+                    }
+                }
+            }
+        },
         "smaps.SingleNewsMap": {
             "type": "object",
             "properties": {
@@ -571,6 +3629,34 @@ const docTemplate = `{
                     "$ref": "#/definitions/services.NewsResponse"
                 }
             }
+        },
+        "smaps.TagsMap": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.TagResponse"
+                    }
+                }
+            }
+        },
+        "smaps.UnreadCountMap": {
+            "type": "object",
+            "properties": {
+                "unread": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "smaps.UploadURLMap": {
+            "type": "object",
+            "properties": {
+                "upload": {
+                    "$ref": "#/definitions/services.UploadURLResponse"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,5 @@
+package forms
+
+type CategoryDTO struct {
+	Name string `json:"name" binding:"required,min=3,max=50" validate:"required" minimum:"3" maximum:"50" example:"Deportes"`
+}
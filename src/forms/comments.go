@@ -0,0 +1,10 @@
+package forms
+
+type CommentDTO struct {
+	Body   string `json:"body" binding:"required,min=1,max=1000" validate:"required" minimum:"1" maximum:"1000"`
+	Parent string `json:"parent" binding:"omitempty" validate:"optional" example:"638660ca141aa4ee9faf07e8"`
+}
+
+type UpdateCommentDTO struct {
+	Body string `json:"body" binding:"required,min=1,max=1000" validate:"required" minimum:"1" maximum:"1000"`
+}
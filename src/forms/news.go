@@ -1,17 +1,46 @@
 package forms
 
-import "mime/multipart"
+import (
+	"mime/multipart"
+	"time"
+)
 
 type NewsDTO struct {
-	Title    string                `form:"title" binding:"required,min=3,max=100" validate:"required" minimum:"3" maximum:"100"`
-	Headline string                `form:"headline" binding:"required,min=3,max=500" validate:"required" minimum:"3" maximum:"500"`
-	Body     string                `form:"body" binding:"required" validate:"required"`
-	Img      *multipart.FileHeader `form:"img" binding:"required,file" validate:"required" swaggertype:"string" format:"binary"`
+	Title       string                `form:"title" binding:"required,min=3,max=100" validate:"required" minimum:"3" maximum:"100"`
+	Headline    string                `form:"headline" binding:"required,min=3,max=500" validate:"required" minimum:"3" maximum:"500"`
+	Body        string                `form:"body" binding:"required" validate:"required"`
+	Img         *multipart.FileHeader `form:"img" binding:"omitempty,file" validate:"optional" swaggertype:"string" format:"binary"`
+	Image       string                `form:"image" binding:"omitempty" validate:"optional" example:"638660ca141aa4ee9faf07e8"`
+	Draft       bool                  `form:"draft" binding:"omitempty" validate:"optional"`
+	PublishAt   time.Time             `form:"publish_at" binding:"omitempty" time_format:"2006-01-02T15:04:05Z07:00" validate:"optional" swaggertype:"string" example:"2022-09-21T20:10:23Z"`
+	ExpiresAt   time.Time             `form:"expires_at" binding:"omitempty" time_format:"2006-01-02T15:04:05Z07:00" validate:"optional" swaggertype:"string" example:"2022-10-21T20:10:23Z"`
+	Category    string                `form:"category" binding:"omitempty" validate:"optional" example:"638660ca141aa4ee9faf07e8"`
+	Tags        []string              `form:"tags" binding:"omitempty,max=10,dive,max=30" validate:"optional" maximum:"10"`
+	Roles       []string              `form:"roles" binding:"omitempty,dive,oneof=f e d c b a" validate:"optional" enum:"f,e,d,c,b,a"`
+	Courses     []string              `form:"courses" binding:"omitempty" validate:"optional"`
+	Levels      []string              `form:"levels" binding:"omitempty" validate:"optional"`
+	RequiresAck bool                  `form:"requires_ack" binding:"omitempty" validate:"optional"`
 }
 
 type UpdateNewsDTO struct {
-	Title    string                `form:"title" binding:"omitempty,min=3,max=100" validate:"optional" minimum:"3" maximum:"100"`
-	Headline string                `form:"headline" binding:"omitempty,min=3,max=500" validate:"optional" minimum:"3" maximum:"500"`
-	Body     string                `form:"body" binding:"omitempty" validate:"optional"`
-	Img      *multipart.FileHeader `form:"img" binding:"omitempty,file" validate:"optional" swaggertype:"string" format:"binary"`
+	Title       string                `form:"title" binding:"omitempty,min=3,max=100" validate:"optional" minimum:"3" maximum:"100"`
+	Headline    string                `form:"headline" binding:"omitempty,min=3,max=500" validate:"optional" minimum:"3" maximum:"500"`
+	Body        string                `form:"body" binding:"omitempty" validate:"optional"`
+	Img         *multipart.FileHeader `form:"img" binding:"omitempty,file" validate:"optional" swaggertype:"string" format:"binary"`
+	Image       string                `form:"image" binding:"omitempty" validate:"optional" example:"638660ca141aa4ee9faf07e8"`
+	ExpiresAt   time.Time             `form:"expires_at" binding:"omitempty" time_format:"2006-01-02T15:04:05Z07:00" validate:"optional" swaggertype:"string" example:"2022-10-21T20:10:23Z"`
+	Category    string                `form:"category" binding:"omitempty" validate:"optional" example:"638660ca141aa4ee9faf07e8"`
+	Tags        []string              `form:"tags" binding:"omitempty,max=10,dive,max=30" validate:"optional" maximum:"10"`
+	Roles       []string              `form:"roles" binding:"omitempty,dive,oneof=f e d c b a" validate:"optional" enum:"f,e,d,c,b,a"`
+	Courses     []string              `form:"courses" binding:"omitempty" validate:"optional"`
+	Levels      []string              `form:"levels" binding:"omitempty" validate:"optional"`
+	RequiresAck *bool                 `form:"requires_ack" binding:"omitempty" validate:"optional"`
+}
+
+type ScheduleNewsDTO struct {
+	PublishAt time.Time `json:"publish_at" binding:"required" validate:"required" swaggertype:"string" example:"2022-09-21T20:10:23Z"`
+}
+
+type ReactionDTO struct {
+	Reaction string `json:"reaction" binding:"required" validate:"required" example:"like"`
 }
@@ -0,0 +1,10 @@
+package forms
+
+type UploadURLDTO struct {
+	ContentType string `json:"content_type" binding:"required,oneof=image/jpeg image/png image/webp image/gif" validate:"required" enum:"image/jpeg,image/png,image/webp,image/gif" example:"image/jpeg"`
+	Size        int64  `json:"size" binding:"required,min=1" validate:"required" minimum:"1" example:"204800"`
+}
+
+type ConfirmUploadDTO struct {
+	Key string `json:"key" binding:"required" validate:"required" example:"news/1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed.jpg"`
+}
@@ -0,0 +1,143 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"io"
+
+	"github.com/disintegration/imaging"
+	// Decoders
+	_ "image/gif"
+	_ "image/png"
+
+	_ "golang.org/x/image/webp"
+)
+
+const JPEG_QUALITY = 85
+
+// Name of the stored original, it is encoded before the variants
+const ORIGINAL_NAME = "original"
+
+// Stored originals are downscaled to this width
+const MAX_ORIGINAL_WIDTH = 2560
+
+// Images processed at the same time. A decoded image takes 4 bytes per
+// pixel, this bounds the memory used by uploads
+const MAX_PROCESSING = 2
+
+var processing = make(chan struct{}, MAX_PROCESSING)
+
+type Variant struct {
+	Name  string
+	Width int
+}
+
+// Widths of the responsive variants, from the smallest
+var VARIANTS = []Variant{
+	{Name: "thumbnail", Width: 320},
+	{Name: "card", Width: 640},
+	{Name: "full", Width: 1280},
+}
+
+// Data is only set while the encoded image is stored
+type Encoded struct {
+	Name        string
+	Width       int
+	Height      int
+	ContentType string
+	Ext         string
+	Data        []byte
+}
+
+// Stores an encoded image, its data is released after the call
+type StoreFunc func(encoded *Encoded) error
+
+type Image struct {
+	Original Encoded
+	Variants []Encoded
+	// Placeholders to show while the image loads
+	BlurHash string
+	Color    string
+}
+
+// Photos are kept as JPEG, other formats may have transparency
+func encode(img image.Image, name string, photo bool) (*Encoded, error) {
+	buf := bytes.NewBuffer(nil)
+	encoded := &Encoded{
+		Name:   name,
+		Width:  img.Bounds().Dx(),
+		Height: img.Bounds().Dy(),
+	}
+	if photo {
+		encoded.ContentType = "image/jpeg"
+		encoded.Ext = "jpg"
+		if err := imaging.Encode(buf, img, imaging.JPEG, imaging.JPEGQuality(JPEG_QUALITY)); err != nil {
+			return nil, err
+		}
+	} else {
+		encoded.ContentType = "image/png"
+		encoded.Ext = "png"
+		if err := imaging.Encode(buf, img, imaging.PNG); err != nil {
+			return nil, err
+		}
+	}
+	encoded.Data = buf.Bytes()
+	return encoded, nil
+}
+
+func encodeAndStore(img image.Image, name string, photo bool, store StoreFunc) (*Encoded, error) {
+	encoded, err := encode(img, name, photo)
+	if err != nil {
+		return nil, err
+	}
+	if err := store(encoded); err != nil {
+		return nil, err
+	}
+	encoded.Data = nil
+	return encoded, nil
+}
+
+// Validate and decode the image with its EXIF orientation applied, then
+// re-encode it. Encoded images do not keep any metadata of the upload.
+// The original and each variant are given to store one at a time
+func Process(file io.ReadSeeker, store StoreFunc) (*Image, error) {
+	format, err := Validate(file)
+	if err != nil {
+		return nil, err
+	}
+	processing <- struct{}{}
+	defer func() { <-processing }()
+
+	img, err := imaging.Decode(file, imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	// The decoded source is released as soon as it is downscaled
+	if img.Bounds().Dx() > MAX_ORIGINAL_WIDTH {
+		img = imaging.Resize(img, MAX_ORIGINAL_WIDTH, 0, imaging.Lanczos)
+	}
+	photo := format == "jpeg"
+	placeholder := getPlaceholderImage(img)
+	processed := &Image{
+		BlurHash: BlurHash(placeholder),
+		Color:    DominantColor(placeholder),
+	}
+	original, err := encodeAndStore(img, ORIGINAL_NAME, photo, store)
+	if err != nil {
+		return nil, err
+	}
+	processed.Original = *original
+	// Variants are never upscaled
+	for _, variant := range VARIANTS {
+		if variant.Width >= original.Width {
+			break
+		}
+		resized := imaging.Resize(img, variant.Width, 0, imaging.Lanczos)
+		encoded, err := encodeAndStore(resized, variant.Name, photo, store)
+		if err != nil {
+			return nil, err
+		}
+		processed.Variants = append(processed.Variants, *encoded)
+	}
+	return processed, nil
+}
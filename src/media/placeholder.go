@@ -0,0 +1,163 @@
+package media
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+
+	"github.com/disintegration/imaging"
+)
+
+// Placeholders are computed over a small copy of the image
+const PLACEHOLDER_SIZE = 32
+
+// Components of the BlurHash, horizontal and vertical
+const (
+	BLURHASH_X = 4
+	BLURHASH_Y = 3
+)
+
+const base83 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+func encode83(value int, length int) string {
+	var result strings.Builder
+	for i := 1; i <= length; i++ {
+		digit := (value / int(math.Pow(83, float64(length-i)))) % 83
+		result.WriteByte(base83[digit])
+	}
+	return result.String()
+}
+
+func sRGBToLinear(value uint8) float64 {
+	v := float64(value) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(value float64) int {
+	v := math.Max(0, math.Min(1, value))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(value float64, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(value), exp), value)
+}
+
+// BlurHash of the image, see https://github.com/woltapp/blurhash
+func BlurHash(img *image.NRGBA) string {
+	width := img.Bounds().Dx()
+	height := img.Bounds().Dy()
+	factors := make([][3]float64, 0, BLURHASH_X*BLURHASH_Y)
+	for j := 0; j < BLURHASH_Y; j++ {
+		for i := 0; i < BLURHASH_X; i++ {
+			normalisation := 2.0
+			if i == 0 && j == 0 {
+				normalisation = 1
+			}
+			var factor [3]float64
+			for y := 0; y < height; y++ {
+				for x := 0; x < width; x++ {
+					basis := normalisation *
+						math.Cos(math.Pi*float64(i)*float64(x)/float64(width)) *
+						math.Cos(math.Pi*float64(j)*float64(y)/float64(height))
+					pixel := img.Pix[y*img.Stride+x*4:]
+					factor[0] += basis * sRGBToLinear(pixel[0])
+					factor[1] += basis * sRGBToLinear(pixel[1])
+					factor[2] += basis * sRGBToLinear(pixel[2])
+				}
+			}
+			scale := 1 / float64(width*height)
+			factors = append(factors, [3]float64{
+				factor[0] * scale,
+				factor[1] * scale,
+				factor[2] * scale,
+			})
+		}
+	}
+	dc := factors[0]
+	ac := factors[1:]
+
+	var hash strings.Builder
+	hash.WriteString(encode83((BLURHASH_X-1)+(BLURHASH_Y-1)*9, 1))
+	maximum := 1.0
+	if len(ac) > 0 {
+		actualMaximum := 0.0
+		for _, factor := range ac {
+			for _, value := range factor {
+				actualMaximum = math.Max(actualMaximum, math.Abs(value))
+			}
+		}
+		quantisedMaximum := int(math.Max(0, math.Min(82, math.Floor(actualMaximum*166-0.5))))
+		maximum = float64(quantisedMaximum+1) / 166
+		hash.WriteString(encode83(quantisedMaximum, 1))
+	} else {
+		hash.WriteString(encode83(0, 1))
+	}
+	hash.WriteString(encode83(
+		(linearToSRGB(dc[0])<<16)+(linearToSRGB(dc[1])<<8)+linearToSRGB(dc[2]),
+		4,
+	))
+	for _, factor := range ac {
+		var quantised [3]int
+		for k, value := range factor {
+			quantised[k] = int(math.Max(0, math.Min(18, math.Floor(signPow(value/maximum, 0.5)*9+9.5))))
+		}
+		hash.WriteString(encode83(quantised[0]*19*19+quantised[1]*19+quantised[2], 2))
+	}
+	return hash.String()
+}
+
+// Most frequent color, pixels are grouped by their 4 most significant bits
+// per channel and the color of the biggest group is its average
+func DominantColor(img *image.NRGBA) string {
+	type bucket struct {
+		count   int
+		r, g, b int
+	}
+	buckets := make(map[int]*bucket)
+	var dominant *bucket
+	for y := 0; y < img.Bounds().Dy(); y++ {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			pixel := img.Pix[y*img.Stride+x*4:]
+			// Transparent pixels are not seen
+			if pixel[3] < 128 {
+				continue
+			}
+			id := int(pixel[0]>>4)<<8 | int(pixel[1]>>4)<<4 | int(pixel[2]>>4)
+			current, ok := buckets[id]
+			if !ok {
+				current = &bucket{}
+				buckets[id] = current
+			}
+			current.count++
+			current.r += int(pixel[0])
+			current.g += int(pixel[1])
+			current.b += int(pixel[2])
+			if dominant == nil || current.count > dominant.count {
+				dominant = current
+			}
+		}
+	}
+	if dominant == nil {
+		return "#ffffff"
+	}
+	return fmt.Sprintf(
+		"#%02x%02x%02x",
+		dominant.r/dominant.count,
+		dominant.g/dominant.count,
+		dominant.b/dominant.count,
+	)
+}
+
+func getPlaceholderImage(img image.Image) *image.NRGBA {
+	if img.Bounds().Dx() >= img.Bounds().Dy() {
+		return imaging.Resize(img, PLACEHOLDER_SIZE, 0, imaging.Box)
+	}
+	return imaging.Resize(img, 0, PLACEHOLDER_SIZE, imaging.Box)
+}
@@ -0,0 +1,79 @@
+package media
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+)
+
+// Content types accepted as images and the format of their decoder
+var ALLOWED_TYPES = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+	"image/webp": "webp",
+}
+
+// Size of the files accepted as images
+const MAX_FILE_SIZE = 20 << 20
+
+// A small file can decode into a huge image, dimensions are checked
+// before decoding the pixels
+const (
+	MAX_DIMENSION = 10000
+	MAX_PIXELS    = 24000000
+)
+
+var (
+	ErrUnsupportedType = fmt.Errorf("tipo de archivo no permitido, solo imágenes JPEG, PNG, GIF o WebP")
+	ErrInvalidImage    = fmt.Errorf("la imagen no es válida")
+	ErrFileTooLarge    = fmt.Errorf("la imagen supera el tamaño máximo de 20MB")
+	ErrImageTooLarge   = fmt.Errorf(
+		"la imagen supera las dimensiones máximas de %dx%d píxeles",
+		MAX_DIMENSION,
+		MAX_DIMENSION,
+	)
+)
+
+// Sniff the content type by the magic bytes, the name and content type
+// sent by the client are ignored. Returns the format of the image
+func Validate(file io.ReadSeeker) (string, error) {
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return "", err
+	}
+	if size > MAX_FILE_SIZE {
+		return "", ErrFileTooLarge
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	header := make([]byte, 512)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", ErrInvalidImage
+	}
+	format, ok := ALLOWED_TYPES[http.DetectContentType(header[:n])]
+	if !ok {
+		return "", ErrUnsupportedType
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	config, decodedFormat, err := image.DecodeConfig(file)
+	if err != nil || decodedFormat != format {
+		return "", ErrInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return "", ErrInvalidImage
+	}
+	if config.Width > MAX_DIMENSION || config.Height > MAX_DIMENSION ||
+		config.Width*config.Height > MAX_PIXELS {
+		return "", ErrImageTooLarge
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return format, nil
+}
@@ -0,0 +1,89 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+)
+
+func encodeImage(t *testing.T, format string, width int, height int) []byte {
+	buf := bytes.NewBuffer(nil)
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	var err error
+	switch format {
+	case "png":
+		err = png.Encode(buf, img)
+	case "jpeg":
+		err = jpeg.Encode(buf, img, nil)
+	case "gif":
+		err = gif.Encode(buf, img, nil)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// Signature and header of a PNG, enough to read its dimensions
+func pngHeader(width uint32, height uint32) []byte {
+	data := make([]byte, 17)
+	copy(data, "IHDR")
+	binary.BigEndian.PutUint32(data[4:], width)
+	binary.BigEndian.PutUint32(data[8:], height)
+	// Bit depth 8, RGBA
+	data[12] = 8
+	data[13] = 6
+	chunk := make([]byte, 4+len(data)+4)
+	binary.BigEndian.PutUint32(chunk, uint32(len(data)-4))
+	copy(chunk[4:], data)
+	binary.BigEndian.PutUint32(chunk[4+len(data):], crc32.ChecksumIEEE(data))
+	return append([]byte("\x89PNG\r\n\x1a\n"), chunk...)
+}
+
+func TestValidate(t *testing.T) {
+	tooBig := make([]byte, MAX_FILE_SIZE+1)
+	copy(tooBig, encodeImage(t, "png", 1, 1))
+	tests := []struct {
+		name   string
+		data   []byte
+		format string
+		err    error
+	}{
+		{"png", encodeImage(t, "png", 4, 3), "png", nil},
+		{"jpeg", encodeImage(t, "jpeg", 4, 3), "jpeg", nil},
+		{"gif", encodeImage(t, "gif", 4, 3), "gif", nil},
+		{"empty", nil, "", ErrInvalidImage},
+		{"text", []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"), "", ErrUnsupportedType},
+		{"pdf", []byte("%PDF-1.4\n"), "", ErrUnsupportedType},
+		{"truncated", encodeImage(t, "png", 4, 3)[:12], "", ErrInvalidImage},
+		{"too wide", pngHeader(MAX_DIMENSION+1, 1), "", ErrImageTooLarge},
+		{"too tall", pngHeader(1, MAX_DIMENSION+1), "", ErrImageTooLarge},
+		{"too many pixels", pngHeader(5000, 5000), "", ErrImageTooLarge},
+		{"max dimension", pngHeader(MAX_DIMENSION, 1), "png", nil},
+		{"file too large", tooBig, "", ErrFileTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := bytes.NewReader(tt.data)
+			format, err := Validate(file)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if format != tt.format {
+				t.Errorf("format = %q, want %q", format, tt.format)
+			}
+			// Valid images are read again from the start
+			if err == nil {
+				if offset, _ := file.Seek(0, io.SeekCurrent); offset != 0 {
+					t.Errorf("offset = %d, want 0", offset)
+				}
+			}
+		})
+	}
+}
@@ -9,9 +9,16 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func RolesMiddleware() gin.HandlerFunc {
+// Only directives, optionally the allowed user types too
+func RolesMiddleware(allowed ...string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		claims, _ := services.NewClaimsFromContext(ctx)
+		for _, userType := range allowed {
+			if claims.UserType == userType {
+				ctx.Next()
+				return
+			}
+		}
 		if claims.UserType == models.TEACHER || claims.UserType == models.ATTORNEY || claims.UserType == models.STUDENT {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, &res.Response{
 				Success: false,
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const ACKNOWLEDGEMENTS_COLLECTION = "acknowledgements"
+
+type Acknowledgement struct {
+	ID     primitive.ObjectID `json:"_id" bson:"_id,omitempty"`
+	NewsID primitive.ObjectID `json:"news" bson:"news"`
+	UserID primitive.ObjectID `json:"user" bson:"user"`
+	Date   primitive.DateTime `json:"date" bson:"date"`
+}
+
+type AcknowledgementsModel struct{}
+
+func init() {
+	collections, errC := DbConnect.GetCollections()
+	if errC != nil {
+		panic(errC)
+	}
+	for _, collection := range collections {
+		if collection == ACKNOWLEDGEMENTS_COLLECTION {
+			createAcknowledgementsIndexes()
+			return
+		}
+	}
+	var jsonSchema = bson.M{
+		"bsonType": "object",
+		"required": []string{
+			"news",
+			"user",
+			"date",
+		},
+		"properties": bson.M{
+			"news": bson.M{"bsonType": "objectId"},
+			"user": bson.M{"bsonType": "objectId"},
+			"date": bson.M{"bsonType": "date"},
+		},
+	}
+	var validators = bson.M{
+		"$jsonSchema": jsonSchema,
+	}
+	opts := &options.CreateCollectionOptions{
+		Validator: validators,
+	}
+	err := DbConnect.CreateCollection(ACKNOWLEDGEMENTS_COLLECTION, opts)
+	if err != nil {
+		panic(err)
+	}
+	createAcknowledgementsIndexes()
+}
+
+// A news is acknowledged once per user
+func createAcknowledgementsIndexes() {
+	_, err := DbConnect.GetCollection(ACKNOWLEDGEMENTS_COLLECTION).Indexes().CreateOne(
+		db.Ctx,
+		mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "news", Value: 1},
+				{Key: "user", Value: 1},
+			},
+			Options: options.Index().SetName("acknowledgements_news_user").SetUnique(true),
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (acknowledgements *AcknowledgementsModel) Use() *mongo.Collection {
+	return DbConnect.GetCollection(ACKNOWLEDGEMENTS_COLLECTION)
+}
+
+func (acknowledgements *AcknowledgementsModel) NewModel(userId, newsId primitive.ObjectID) *Acknowledgement {
+	return &Acknowledgement{
+		UserID: userId,
+		NewsID: newsId,
+		Date:   primitive.NewDateTimeFromTime(time.Now()),
+	}
+}
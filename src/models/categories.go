@@ -0,0 +1,102 @@
+package models
+
+import (
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"github.com/gosimple/slug"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const CATEGORIES_COLLECTION = "categories"
+
+type Category struct {
+	ID     primitive.ObjectID `json:"_id" bson:"_id,omitempty"`
+	Name   string             `json:"name" bson:"name"`
+	Slug   string             `json:"slug" bson:"slug"`
+	Status bool               `json:"status" bson:"status"`
+	Date   primitive.DateTime `json:"date" bson:"date"`
+}
+
+type CategoriesModel struct{}
+
+func init() {
+	collections, errC := DbConnect.GetCollections()
+	if errC != nil {
+		panic(errC)
+	}
+	for _, collection := range collections {
+		if collection == CATEGORIES_COLLECTION {
+			createCategoriesIndexes()
+			return
+		}
+	}
+	var jsonSchema = bson.M{
+		"bsonType": "object",
+		"required": []string{
+			"name",
+			"slug",
+			"status",
+			"date",
+		},
+		"properties": bson.M{
+			"name": bson.M{
+				"bsonType":  "string",
+				"maxLength": 50,
+			},
+			"slug":   bson.M{"bsonType": "string"},
+			"status": bson.M{"bsonType": "bool"},
+			"date":   bson.M{"bsonType": "date"},
+		},
+	}
+	var validators = bson.M{
+		"$jsonSchema": jsonSchema,
+	}
+	opts := &options.CreateCollectionOptions{
+		Validator: validators,
+	}
+	err := DbConnect.CreateCollection(CATEGORIES_COLLECTION, opts)
+	if err != nil {
+		panic(err)
+	}
+	createCategoriesIndexes()
+}
+
+func createCategoriesIndexes() {
+	// The slug was unique among deleted categories too, so the name of a
+	// deleted category could not be used again
+	DbConnect.GetCollection(CATEGORIES_COLLECTION).Indexes().DropOne(db.Ctx, "categories_slug")
+	_, err := DbConnect.GetCollection(CATEGORIES_COLLECTION).Indexes().CreateOne(
+		db.Ctx,
+		mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "slug", Value: 1},
+			},
+			Options: options.Index().
+				SetName("categories_slug_active").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{
+					"status": true,
+				}),
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (categories *CategoriesModel) Use() *mongo.Collection {
+	return DbConnect.GetCollection(CATEGORIES_COLLECTION)
+}
+
+func (categories *CategoriesModel) NewModel(name string) *Category {
+	return &Category{
+		Name:   name,
+		Slug:   slug.MakeLang(name, "es"),
+		Status: true,
+		Date:   primitive.NewDateTimeFromTime(time.Now()),
+	}
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const COMMENTS_COLLECTION = "comments"
+
+type Comment struct {
+	ID         primitive.ObjectID `json:"_id" bson:"_id,omitempty"`
+	NewsID     primitive.ObjectID `json:"news" bson:"news"`
+	UserID     primitive.ObjectID `json:"user" bson:"user"`
+	Parent     primitive.ObjectID `json:"parent,omitempty" bson:"parent,omitempty"`
+	Body       string             `json:"body" bson:"body"`
+	Hidden     bool               `json:"hidden" bson:"hidden"`
+	Status     bool               `json:"status" bson:"status"`
+	UploadDate primitive.DateTime `json:"upload_date" bson:"upload_date"`
+	UpdateDate primitive.DateTime `json:"update_date" bson:"update_date"`
+}
+
+type CommentsModel struct{}
+
+func init() {
+	collections, errC := DbConnect.GetCollections()
+	if errC != nil {
+		panic(errC)
+	}
+	for _, collection := range collections {
+		if collection == COMMENTS_COLLECTION {
+			createCommentsIndexes()
+			return
+		}
+	}
+	var jsonSchema = bson.M{
+		"bsonType": "object",
+		"required": []string{
+			"news",
+			"user",
+			"body",
+			"hidden",
+			"status",
+			"upload_date",
+			"update_date",
+		},
+		"properties": bson.M{
+			"news":   bson.M{"bsonType": "objectId"},
+			"user":   bson.M{"bsonType": "objectId"},
+			"parent": bson.M{"bsonType": "objectId"},
+			"body": bson.M{
+				"bsonType":  "string",
+				"maxLength": 1000,
+			},
+			"hidden":      bson.M{"bsonType": "bool"},
+			"status":      bson.M{"bsonType": "bool"},
+			"upload_date": bson.M{"bsonType": "date"},
+			"update_date": bson.M{"bsonType": "date"},
+		},
+	}
+	var validators = bson.M{
+		"$jsonSchema": jsonSchema,
+	}
+	opts := &options.CreateCollectionOptions{
+		Validator: validators,
+	}
+	err := DbConnect.CreateCollection(COMMENTS_COLLECTION, opts)
+	if err != nil {
+		panic(err)
+	}
+	createCommentsIndexes()
+}
+
+func createCommentsIndexes() {
+	_, err := DbConnect.GetCollection(COMMENTS_COLLECTION).Indexes().CreateOne(
+		db.Ctx,
+		mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "news", Value: 1},
+				{Key: "parent", Value: 1},
+				{Key: "upload_date", Value: 1},
+			},
+			Options: options.Index().SetName("comments_news_parent"),
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (comments *CommentsModel) Use() *mongo.Collection {
+	return DbConnect.GetCollection(COMMENTS_COLLECTION)
+}
+
+func (comments *CommentsModel) NewModel(
+	newsId primitive.ObjectID,
+	userId primitive.ObjectID,
+	parent primitive.ObjectID,
+	body string,
+) *Comment {
+	now := primitive.NewDateTimeFromTime(time.Now())
+	return &Comment{
+		NewsID:     newsId,
+		UserID:     userId,
+		Parent:     parent,
+		Body:       body,
+		Hidden:     false,
+		Status:     true,
+		UploadDate: now,
+		UpdateDate: now,
+	}
+}
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const MEDIA_COLLECTION = "media"
+
+type MediaVariant struct {
+	Name   string `json:"name" bson:"name"`
+	Width  int    `json:"width" bson:"width"`
+	Height int    `json:"height" bson:"height"`
+	Key    string `json:"key" bson:"key"`
+}
+
+// Processed image, it has the same ID of its file. Images are stored once
+// by the SHA-256 of their content and counted by the news that use them.
+// The uploader is the user that stored the content first
+type Media struct {
+	ID       primitive.ObjectID `json:"_id" bson:"_id"`
+	Key      string             `json:"key" bson:"key"`
+	Hash     string             `json:"hash,omitempty" bson:"hash,omitempty"`
+	Refs     int                `json:"refs" bson:"refs"`
+	Uploader primitive.ObjectID `json:"uploader,omitempty" bson:"uploader,omitempty"`
+	Width    int                `json:"width" bson:"width"`
+	Height   int                `json:"height" bson:"height"`
+	Variants []MediaVariant     `json:"variants" bson:"variants"`
+	BlurHash string             `json:"blurhash,omitempty" bson:"blurhash,omitempty"`
+	Color    string             `json:"color,omitempty" bson:"color,omitempty"`
+	Date     primitive.DateTime `json:"date" bson:"date"`
+}
+
+type MediaModel struct{}
+
+func init() {
+	collections, errC := DbConnect.GetCollections()
+	if errC != nil {
+		panic(errC)
+	}
+	for _, collection := range collections {
+		if collection == MEDIA_COLLECTION {
+			createMediaIndexes()
+			return
+		}
+	}
+	var jsonSchema = bson.M{
+		"bsonType": "object",
+		"required": []string{
+			"key",
+			"width",
+			"height",
+			"variants",
+			"date",
+		},
+		"properties": bson.M{
+			"key":      bson.M{"bsonType": "string"},
+			"hash":     bson.M{"bsonType": "string"},
+			"refs":     bson.M{"bsonType": "number"},
+			"uploader": bson.M{"bsonType": "objectId"},
+			"width":    bson.M{"bsonType": "number"},
+			"height":   bson.M{"bsonType": "number"},
+			"variants": bson.M{
+				"bsonType": "array",
+				"items": bson.M{
+					"bsonType": "object",
+					"required": []string{"name", "width", "height", "key"},
+					"properties": bson.M{
+						"name":   bson.M{"bsonType": "string"},
+						"width":  bson.M{"bsonType": "number"},
+						"height": bson.M{"bsonType": "number"},
+						"key":    bson.M{"bsonType": "string"},
+					},
+				},
+			},
+			"blurhash": bson.M{"bsonType": "string"},
+			"color":    bson.M{"bsonType": "string"},
+			"date":     bson.M{"bsonType": "date"},
+		},
+	}
+	var validators = bson.M{
+		"$jsonSchema": jsonSchema,
+	}
+	opts := &options.CreateCollectionOptions{
+		Validator: validators,
+	}
+	err := DbConnect.CreateCollection(MEDIA_COLLECTION, opts)
+	if err != nil {
+		panic(err)
+	}
+	createMediaIndexes()
+}
+
+// Images processed before the deduplication have no hash. The library is
+// listed by date, of all users or only of the uploader
+func createMediaIndexes() {
+	_, err := DbConnect.GetCollection(MEDIA_COLLECTION).Indexes().CreateMany(
+		db.Ctx,
+		[]mongo.IndexModel{
+			{
+				Keys: bson.D{
+					{Key: "hash", Value: 1},
+				},
+				Options: options.Index().
+					SetName("media_hash").
+					SetUnique(true).
+					SetPartialFilterExpression(bson.M{
+						"hash": bson.M{"$exists": true},
+					}),
+			},
+			{
+				Keys: bson.D{
+					{Key: "date", Value: -1},
+				},
+				Options: options.Index().SetName("media_date"),
+			},
+			{
+				Keys: bson.D{
+					{Key: "uploader", Value: 1},
+					{Key: "date", Value: -1},
+				},
+				Options: options.Index().SetName("media_uploader_date"),
+			},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (media *MediaModel) Use() *mongo.Collection {
+	return DbConnect.GetCollection(MEDIA_COLLECTION)
+}
+
+func (media *MediaModel) NewModel(
+	fileId primitive.ObjectID,
+	key string,
+	hash string,
+	uploader primitive.ObjectID,
+	width int,
+	height int,
+	variants []MediaVariant,
+	blurHash string,
+	color string,
+) *Media {
+	if variants == nil {
+		variants = []MediaVariant{}
+	}
+	return &Media{
+		ID:       fileId,
+		Key:      key,
+		Hash:     hash,
+		Uploader: uploader,
+		Width:    width,
+		Height:   height,
+		Variants: variants,
+		BlurHash: blurHash,
+		Color:    color,
+		Date:     primitive.NewDateTimeFromTime(time.Now()),
+	}
+}
@@ -2,8 +2,10 @@ package models
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/CPU-commits/Intranet_BNews/src/db"
 	"github.com/CPU-commits/Intranet_BNews/src/forms"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -13,18 +15,48 @@ import (
 
 const NEWS_COLLECTION = "news"
 
+// News states
+const (
+	NEWS_STATE_DRAFT     = "draft"
+	NEWS_STATE_SCHEDULED = "scheduled"
+	NEWS_STATE_PUBLISHED = "published"
+	NEWS_STATE_ARCHIVED  = "archived"
+)
+
+// Readers of a news, an empty list allows everyone
+type Audience struct {
+	Roles   []string             `json:"roles" bson:"roles"`
+	Courses []primitive.ObjectID `json:"courses" bson:"courses"`
+	Levels  []primitive.ObjectID `json:"levels" bson:"levels"`
+}
+
 type News struct {
-	ID         primitive.ObjectID `json:"_id" bson:"_id,omitempty"`
-	AuthorId   primitive.ObjectID `json:"author_id,omitempty" bson:"author_id,omitempty"`
-	Title      string             `json:"title" bson:"title"`
-	Headline   string             `json:"headline" bson:"headline"`
-	Body       string             `json:"body" bson:"body"`
-	Img        primitive.ObjectID `json:"img" bson:"img"`
-	Url        string             `json:"url" bson:"url"`
-	Type       string             `json:"type" bson:"type"`
-	Status     bool               `json:"status" bson:"status"`
-	UploadDate primitive.DateTime `json:"upload_date" bson:"upload_date"`
-	UpdateDate primitive.DateTime `json:"update_date" bson:"update_date"`
+	ID               primitive.ObjectID `json:"_id" bson:"_id,omitempty"`
+	AuthorId         primitive.ObjectID `json:"author_id,omitempty" bson:"author_id,omitempty"`
+	Title            string             `json:"title" bson:"title"`
+	Headline         string             `json:"headline" bson:"headline"`
+	Body             string             `json:"body" bson:"body"`
+	Img              primitive.ObjectID `json:"img" bson:"img"`
+	Url              string             `json:"url" bson:"url"`
+	Type             string             `json:"type" bson:"type"`
+	Status           bool               `json:"status" bson:"status"`
+	State            string             `json:"state" bson:"state"`
+	PublishAt        primitive.DateTime `json:"publish_at,omitempty" bson:"publish_at,omitempty"`
+	ExpiresAt        primitive.DateTime `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+	Reactions        map[string]int     `json:"reactions" bson:"reactions"`
+	Comments         int                `json:"comments" bson:"comments"`
+	CommentsDisabled bool               `json:"comments_disabled" bson:"comments_disabled"`
+	Category         primitive.ObjectID `json:"category,omitempty" bson:"category,omitempty"`
+	Tags             []string           `json:"tags" bson:"tags"`
+	Audience         *Audience          `json:"audience,omitempty" bson:"audience,omitempty"`
+	RequiresAck      bool               `json:"requires_ack" bson:"requires_ack"`
+	DeletedAt        primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+	DeletedBy        primitive.ObjectID `json:"deleted_by,omitempty" bson:"deleted_by,omitempty"`
+	Version          int                `json:"version" bson:"version"`
+	UploadDate       primitive.DateTime `json:"upload_date" bson:"upload_date"`
+	UpdateDate       primitive.DateTime `json:"update_date" bson:"update_date"`
+	// Last change of the counters and states, they are not an update
+	ActivityDate primitive.DateTime `json:"activity_date,omitempty" bson:"activity_date,omitempty"`
 }
 
 type NewsModel struct{}
@@ -49,6 +81,8 @@ func init() {
 	}
 	for _, collection := range collections {
 		if collection == NEWS_COLLECTION {
+			migrateNews()
+			createNewsIndexes()
 			return
 		}
 	}
@@ -62,6 +96,7 @@ func init() {
 			"url",
 			"type",
 			"status",
+			"state",
 			"upload_date",
 			"update_date",
 		},
@@ -75,11 +110,46 @@ func init() {
 				"bsonType":  "string",
 				"maxLength": 500,
 			},
-			"body":        bson.M{"bsonType": "string"},
-			"img":         bson.M{"bsonType": "objectId"},
-			"url":         bson.M{"bsonType": "string"},
-			"type":        bson.M{"enum": bson.A{"student", "global"}},
-			"status":      bson.M{"bsonType": "bool"},
+			"body":              bson.M{"bsonType": "string"},
+			"img":               bson.M{"bsonType": "objectId"},
+			"url":               bson.M{"bsonType": "string"},
+			"type":              bson.M{"enum": bson.A{"student", "global"}},
+			"status":            bson.M{"bsonType": "bool"},
+			"state":             bson.M{"enum": bson.A{NEWS_STATE_DRAFT, NEWS_STATE_SCHEDULED, NEWS_STATE_PUBLISHED, NEWS_STATE_ARCHIVED}},
+			"publish_at":        bson.M{"bsonType": "date"},
+			"expires_at":        bson.M{"bsonType": "date"},
+			"reactions":         bson.M{"bsonType": "object"},
+			"comments":          bson.M{"bsonType": "number"},
+			"comments_disabled": bson.M{"bsonType": "bool"},
+			"category":          bson.M{"bsonType": "objectId"},
+			"tags": bson.M{
+				"bsonType": "array",
+				"items":    bson.M{"bsonType": "string"},
+			},
+			"requires_ack":  bson.M{"bsonType": "bool"},
+			"deleted_at":    bson.M{"bsonType": "date"},
+			"deleted_by":    bson.M{"bsonType": "objectId"},
+			"version":       bson.M{"bsonType": "number"},
+			"activity_date": bson.M{"bsonType": "date"},
+			"audience": bson.M{
+				"bsonType": "object",
+				"properties": bson.M{
+					"roles": bson.M{
+						"bsonType": "array",
+						"items": bson.M{
+							"enum": bson.A{DIRECTOR, DIRECTIVE, TEACHER, ATTORNEY, STUDENT_DIRECTIVE, STUDENT},
+						},
+					},
+					"courses": bson.M{
+						"bsonType": "array",
+						"items":    bson.M{"bsonType": "objectId"},
+					},
+					"levels": bson.M{
+						"bsonType": "array",
+						"items":    bson.M{"bsonType": "objectId"},
+					},
+				},
+			},
 			"upload_date": bson.M{"bsonType": "date"},
 			"update_date": bson.M{"bsonType": "date"},
 		},
@@ -94,6 +164,103 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	createNewsIndexes()
+}
+
+func createNewsIndexes() {
+	_, err := DbConnect.GetCollection(NEWS_COLLECTION).Indexes().CreateMany(
+		db.Ctx,
+		[]mongo.IndexModel{
+			{
+				Keys: bson.D{
+					{Key: "title", Value: "text"},
+					{Key: "headline", Value: "text"},
+					{Key: "body", Value: "text"},
+				},
+				Options: options.Index().
+					SetName("news_text").
+					SetDefaultLanguage("spanish").
+					SetWeights(bson.D{
+						{Key: "title", Value: 10},
+						{Key: "headline", Value: 5},
+						{Key: "body", Value: 1},
+					}),
+			},
+			{
+				Keys: bson.D{
+					{Key: "upload_date", Value: -1},
+					{Key: "_id", Value: -1},
+				},
+				Options: options.Index().SetName("news_upload_date"),
+			},
+			{
+				Keys: bson.D{
+					{Key: "category", Value: 1},
+				},
+				Options: options.Index().SetName("news_category"),
+			},
+			{
+				Keys: bson.D{
+					{Key: "tags", Value: 1},
+				},
+				Options: options.Index().SetName("news_tags"),
+			},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Update news created by older versions of the service
+func migrateNews() {
+	// News created before the publishing workflow have no state,
+	// they were visible as soon as they were uploaded
+	_, err := DbConnect.GetCollection(NEWS_COLLECTION).UpdateMany(
+		db.Ctx,
+		bson.D{
+			{
+				Key: "state",
+				Value: bson.M{
+					"$exists": false,
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$set",
+				Value: bson.M{
+					"state": NEWS_STATE_PUBLISHED,
+				},
+			},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+	// Versions start with the first edit of the news
+	_, err = DbConnect.GetCollection(NEWS_COLLECTION).UpdateMany(
+		db.Ctx,
+		bson.D{
+			{
+				Key: "version",
+				Value: bson.M{
+					"$exists": false,
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$set",
+				Value: bson.M{
+					"version": 1,
+				},
+			},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
 }
 
 func (news *NewsModel) Use() *mongo.Collection {
@@ -114,17 +281,99 @@ func (news *NewsModel) NewModel(data forms.NewsDTO, imageId, slugNews, typeNews,
 	if err != nil {
 		return &News{}, err
 	}
-	now := primitive.NewDateTimeFromTime(time.Now())
+	var categoryObjectId primitive.ObjectID
+	if data.Category != "" {
+		categoryObjectId, err = primitive.ObjectIDFromHex(data.Category)
+		if err != nil {
+			return &News{}, err
+		}
+	}
+	audience, err := NewAudience(data.Roles, data.Courses, data.Levels)
+	if err != nil {
+		return &News{}, err
+	}
+	now := time.Now()
+	// State
+	state := NEWS_STATE_PUBLISHED
+	var publishAt primitive.DateTime
+	if data.Draft {
+		state = NEWS_STATE_DRAFT
+	} else if data.PublishAt.After(now) {
+		state = NEWS_STATE_SCHEDULED
+		publishAt = primitive.NewDateTimeFromTime(data.PublishAt)
+	}
+	var expiresAt primitive.DateTime
+	if !data.ExpiresAt.IsZero() {
+		expiresAt = primitive.NewDateTimeFromTime(data.ExpiresAt)
+	}
 	return &News{
-		AuthorId:   authorObjectId,
-		Title:      data.Title,
-		Headline:   data.Headline,
-		Body:       data.Body,
-		Img:        imgObjectId,
-		Url:        slugNews,
-		Type:       typeNews,
-		Status:     true,
-		UploadDate: now,
-		UpdateDate: now,
+		AuthorId:    authorObjectId,
+		Title:       data.Title,
+		Headline:    data.Headline,
+		Body:        data.Body,
+		Img:         imgObjectId,
+		Url:         slugNews,
+		Type:        typeNews,
+		Status:      true,
+		State:       state,
+		PublishAt:   publishAt,
+		ExpiresAt:   expiresAt,
+		Reactions:   map[string]int{},
+		Category:    categoryObjectId,
+		Tags:        NormalizeTags(data.Tags),
+		Audience:    audience,
+		RequiresAck: data.RequiresAck,
+		Version:     1,
+		UploadDate:  primitive.NewDateTimeFromTime(now),
+		UpdateDate:  primitive.NewDateTimeFromTime(now),
 	}, nil
 }
+
+// Tags are lowercase and unique
+func NormalizeTags(tags []string) []string {
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		repeated := false
+		for _, added := range normalized {
+			if added == tag {
+				repeated = true
+				break
+			}
+		}
+		if !repeated {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+func NewAudience(roles, courses, levels []string) (*Audience, error) {
+	if len(roles) == 0 && len(courses) == 0 && len(levels) == 0 {
+		return nil, nil
+	}
+	audience := &Audience{
+		Roles:   []string{},
+		Courses: []primitive.ObjectID{},
+		Levels:  []primitive.ObjectID{},
+	}
+	audience.Roles = append(audience.Roles, roles...)
+	for _, course := range courses {
+		courseObjectId, err := primitive.ObjectIDFromHex(course)
+		if err != nil {
+			return nil, err
+		}
+		audience.Courses = append(audience.Courses, courseObjectId)
+	}
+	for _, level := range levels {
+		levelObjectId, err := primitive.ObjectIDFromHex(level)
+		if err != nil {
+			return nil, err
+		}
+		audience.Levels = append(audience.Levels, levelObjectId)
+	}
+	return audience, nil
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const REACTIONS_COLLECTION = "reactions"
+
+// Likes were stored in their own collection before reactions
+const LIKES_COLLECTION = "likes"
+
+const REACTION_LIKE = "like"
+
+type Reactions struct {
+	ID       primitive.ObjectID `json:"_id" bson:"_id,omitempty"`
+	NewsID   primitive.ObjectID `json:"news" bson:"news"`
+	UserID   primitive.ObjectID `json:"user" bson:"user"`
+	Reaction string             `json:"reaction" bson:"reaction"`
+	Date     primitive.DateTime `json:"date" bson:"date"`
+}
+
+type ReactionsModel struct{}
+
+func init() {
+	collections, errC := DbConnect.GetCollections()
+	if errC != nil {
+		panic(errC)
+	}
+	hasLikes := false
+	for _, collection := range collections {
+		if collection == REACTIONS_COLLECTION {
+			createReactionsIndexes()
+			return
+		}
+		if collection == LIKES_COLLECTION {
+			hasLikes = true
+		}
+	}
+	var jsonSchema = bson.M{
+		"bsonType": "object",
+		"required": []string{
+			"news",
+			"user",
+			"reaction",
+			"date",
+		},
+		"properties": bson.M{
+			"news":     bson.M{"bsonType": "objectId"},
+			"user":     bson.M{"bsonType": "objectId"},
+			"reaction": bson.M{"bsonType": "string"},
+			"date":     bson.M{"bsonType": "date"},
+		},
+	}
+	var validators = bson.M{
+		"$jsonSchema": jsonSchema,
+	}
+	opts := &options.CreateCollectionOptions{
+		Validator: validators,
+	}
+	err := DbConnect.CreateCollection(REACTIONS_COLLECTION, opts)
+	if err != nil {
+		panic(err)
+	}
+	createReactionsIndexes()
+	if hasLikes {
+		migrateLikes()
+	}
+}
+
+// A user has only one reaction per news
+func createReactionsIndexes() {
+	_, err := DbConnect.GetCollection(REACTIONS_COLLECTION).Indexes().CreateOne(
+		db.Ctx,
+		mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "news", Value: 1},
+				{Key: "user", Value: 1},
+			},
+			Options: options.Index().SetName("reactions_news_user").SetUnique(true),
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Every like becomes a like reaction
+func migrateLikes() {
+	_, err := DbConnect.GetCollection(LIKES_COLLECTION).Aggregate(db.Ctx, mongo.Pipeline{
+		bson.D{
+			{
+				Key: "$project",
+				Value: bson.M{
+					"news":     1,
+					"user":     1,
+					"reaction": REACTION_LIKE,
+					"date": bson.M{
+						"$toDate": "$_id",
+					},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$merge",
+				Value: bson.M{
+					"into":           REACTIONS_COLLECTION,
+					"whenMatched":    "keepExisting",
+					"whenNotMatched": "insert",
+				},
+			},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (reactions *ReactionsModel) Use() *mongo.Collection {
+	return DbConnect.GetCollection(REACTIONS_COLLECTION)
+}
+
+func (reactions *ReactionsModel) NewModel(userId, newsId primitive.ObjectID, reaction string) *Reactions {
+	return &Reactions{
+		UserID:   userId,
+		NewsID:   newsId,
+		Reaction: reaction,
+		Date:     primitive.NewDateTimeFromTime(time.Now()),
+	}
+}
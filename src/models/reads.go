@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const READS_COLLECTION = "reads"
+
+type Read struct {
+	ID     primitive.ObjectID `json:"_id" bson:"_id,omitempty"`
+	NewsID primitive.ObjectID `json:"news" bson:"news"`
+	UserID primitive.ObjectID `json:"user" bson:"user"`
+	Date   primitive.DateTime `json:"date" bson:"date"`
+}
+
+type ReadsModel struct{}
+
+func init() {
+	collections, errC := DbConnect.GetCollections()
+	if errC != nil {
+		panic(errC)
+	}
+	for _, collection := range collections {
+		if collection == READS_COLLECTION {
+			createReadsIndexes()
+			return
+		}
+	}
+	var jsonSchema = bson.M{
+		"bsonType": "object",
+		"required": []string{
+			"news",
+			"user",
+			"date",
+		},
+		"properties": bson.M{
+			"news": bson.M{"bsonType": "objectId"},
+			"user": bson.M{"bsonType": "objectId"},
+			"date": bson.M{"bsonType": "date"},
+		},
+	}
+	var validators = bson.M{
+		"$jsonSchema": jsonSchema,
+	}
+	opts := &options.CreateCollectionOptions{
+		Validator: validators,
+	}
+	err := DbConnect.CreateCollection(READS_COLLECTION, opts)
+	if err != nil {
+		panic(err)
+	}
+	createReadsIndexes()
+}
+
+// A news is read once per user
+func createReadsIndexes() {
+	_, err := DbConnect.GetCollection(READS_COLLECTION).Indexes().CreateOne(
+		db.Ctx,
+		mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "news", Value: 1},
+				{Key: "user", Value: 1},
+			},
+			Options: options.Index().SetName("reads_news_user").SetUnique(true),
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (reads *ReadsModel) Use() *mongo.Collection {
+	return DbConnect.GetCollection(READS_COLLECTION)
+}
+
+func (reads *ReadsModel) NewModel(userId, newsId primitive.ObjectID) *Read {
+	return &Read{
+		UserID: userId,
+		NewsID: newsId,
+		Date:   primitive.NewDateTimeFromTime(time.Now()),
+	}
+}
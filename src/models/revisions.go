@@ -0,0 +1,134 @@
+package models
+
+import (
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const REVISIONS_COLLECTION = "revisions"
+
+// Content of the news fields kept by a revision
+var REVISION_FIELDS = []string{"title", "headline", "body", "img"}
+
+// Immutable content of a news after an edit
+type Revision struct {
+	ID           primitive.ObjectID `json:"_id" bson:"_id,omitempty"`
+	NewsID       primitive.ObjectID `json:"news" bson:"news"`
+	EditorID     primitive.ObjectID `json:"editor" bson:"editor"`
+	Changes      []string           `json:"changes" bson:"changes"`
+	Title        string             `json:"title" bson:"title"`
+	Headline     string             `json:"headline" bson:"headline"`
+	Body         string             `json:"body" bson:"body"`
+	Img          primitive.ObjectID `json:"img" bson:"img"`
+	RestoredFrom primitive.ObjectID `json:"restored_from,omitempty" bson:"restored_from,omitempty"`
+	Date         primitive.DateTime `json:"date" bson:"date"`
+}
+
+type RevisionsModel struct{}
+
+func init() {
+	collections, errC := DbConnect.GetCollections()
+	if errC != nil {
+		panic(errC)
+	}
+	for _, collection := range collections {
+		if collection == REVISIONS_COLLECTION {
+			createRevisionsIndexes()
+			return
+		}
+	}
+	var jsonSchema = bson.M{
+		"bsonType": "object",
+		"required": []string{
+			"news",
+			"editor",
+			"changes",
+			"title",
+			"headline",
+			"body",
+			"img",
+			"date",
+		},
+		"properties": bson.M{
+			"news":   bson.M{"bsonType": "objectId"},
+			"editor": bson.M{"bsonType": "objectId"},
+			"changes": bson.M{
+				"bsonType": "array",
+				"items":    bson.M{"bsonType": "string"},
+			},
+			"title":         bson.M{"bsonType": "string"},
+			"headline":      bson.M{"bsonType": "string"},
+			"body":          bson.M{"bsonType": "string"},
+			"img":           bson.M{"bsonType": "objectId"},
+			"restored_from": bson.M{"bsonType": "objectId"},
+			"date":          bson.M{"bsonType": "date"},
+		},
+	}
+	var validators = bson.M{
+		"$jsonSchema": jsonSchema,
+	}
+	opts := &options.CreateCollectionOptions{
+		Validator: validators,
+	}
+	err := DbConnect.CreateCollection(REVISIONS_COLLECTION, opts)
+	if err != nil {
+		panic(err)
+	}
+	createRevisionsIndexes()
+}
+
+func createRevisionsIndexes() {
+	_, err := DbConnect.GetCollection(REVISIONS_COLLECTION).Indexes().CreateOne(
+		db.Ctx,
+		mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "news", Value: 1},
+				{Key: "date", Value: -1},
+			},
+			Options: options.Index().SetName("revisions_news_date"),
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (revisions *RevisionsModel) Use() *mongo.Collection {
+	return DbConnect.GetCollection(REVISIONS_COLLECTION)
+}
+
+func (revisions *RevisionsModel) NewModel(
+	news *News,
+	editorId primitive.ObjectID,
+	changes []string,
+	date primitive.DateTime,
+) *Revision {
+	return &Revision{
+		NewsID:   news.ID,
+		EditorID: editorId,
+		Changes:  changes,
+		Title:    news.Title,
+		Headline: news.Headline,
+		Body:     news.Body,
+		Img:      news.Img,
+		Date:     date,
+	}
+}
+
+// Value of a field kept by the revision
+func (revision *Revision) Field(field string) string {
+	switch field {
+	case "title":
+		return revision.Title
+	case "headline":
+		return revision.Headline
+	case "body":
+		return revision.Body
+	case "img":
+		return revision.Img.Hex()
+	}
+	return ""
+}
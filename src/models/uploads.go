@@ -0,0 +1,124 @@
+package models
+
+import (
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const UPLOADS_COLLECTION = "uploads"
+
+// Uploads not processed are swept with their objects after this time
+const UPLOADS_RETENTION = 24 * time.Hour
+
+// Direct upload issued to a user. Once confirmed for a news it is pending
+// of processing, the processing date is set while a worker processes it
+type Upload struct {
+	ID             primitive.ObjectID `json:"_id" bson:"_id,omitempty"`
+	Key            string             `json:"key" bson:"key"`
+	UserID         primitive.ObjectID `json:"user" bson:"user"`
+	ContentType    string             `json:"content_type" bson:"content_type"`
+	Date           primitive.DateTime `json:"date" bson:"date"`
+	News           primitive.ObjectID `json:"news,omitempty" bson:"news,omitempty"`
+	UserType       string             `json:"user_type,omitempty" bson:"user_type,omitempty"`
+	ConfirmedDate  primitive.DateTime `json:"confirmed_date,omitempty" bson:"confirmed_date,omitempty"`
+	ProcessingDate primitive.DateTime `json:"processing_date,omitempty" bson:"processing_date,omitempty"`
+}
+
+type UploadsModel struct{}
+
+func init() {
+	collections, errC := DbConnect.GetCollections()
+	if errC != nil {
+		panic(errC)
+	}
+	for _, collection := range collections {
+		if collection == UPLOADS_COLLECTION {
+			createUploadsIndexes()
+			return
+		}
+	}
+	var jsonSchema = bson.M{
+		"bsonType": "object",
+		"required": []string{
+			"key",
+			"user",
+			"content_type",
+			"date",
+		},
+		"properties": bson.M{
+			"key":             bson.M{"bsonType": "string"},
+			"user":            bson.M{"bsonType": "objectId"},
+			"content_type":    bson.M{"bsonType": "string"},
+			"date":            bson.M{"bsonType": "date"},
+			"news":            bson.M{"bsonType": "objectId"},
+			"user_type":       bson.M{"bsonType": "string"},
+			"confirmed_date":  bson.M{"bsonType": "date"},
+			"processing_date": bson.M{"bsonType": "date"},
+		},
+	}
+	var validators = bson.M{
+		"$jsonSchema": jsonSchema,
+	}
+	opts := &options.CreateCollectionOptions{
+		Validator: validators,
+	}
+	err := DbConnect.CreateCollection(UPLOADS_COLLECTION, opts)
+	if err != nil {
+		panic(err)
+	}
+	createUploadsIndexes()
+}
+
+func createUploadsIndexes() {
+	// Expired records were removed by a TTL index, which left their objects
+	// in the bucket. They are swept by the service now
+	DbConnect.GetCollection(UPLOADS_COLLECTION).Indexes().DropOne(db.Ctx, "uploads_date")
+	_, err := DbConnect.GetCollection(UPLOADS_COLLECTION).Indexes().CreateMany(
+		db.Ctx,
+		[]mongo.IndexModel{
+			{
+				Keys: bson.D{
+					{Key: "key", Value: 1},
+				},
+				Options: options.Index().SetName("uploads_key").SetUnique(true),
+			},
+			{
+				Keys: bson.D{
+					{Key: "date", Value: 1},
+				},
+				Options: options.Index().SetName("uploads_date_sweep"),
+			},
+			{
+				Keys: bson.D{
+					{Key: "confirmed_date", Value: 1},
+				},
+				Options: options.Index().
+					SetName("uploads_confirmed").
+					SetPartialFilterExpression(bson.M{
+						"confirmed_date": bson.M{"$exists": true},
+					}),
+			},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (uploads *UploadsModel) Use() *mongo.Collection {
+	return DbConnect.GetCollection(UPLOADS_COLLECTION)
+}
+
+func (uploads *UploadsModel) NewModel(key string, userId primitive.ObjectID, contentType string) *Upload {
+	return &Upload{
+		Key:         key,
+		UserID:      userId,
+		ContentType: contentType,
+		Date:        primitive.NewDateTimeFromTime(time.Now()),
+	}
+}
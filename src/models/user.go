@@ -1,5 +1,12 @@
 package models
 
+import "go.mongodb.org/mongo-driver/mongo"
+
+// Users are managed by the intranet, this service only reads them. Besides
+// their names, documents have the user_type of the JWT and status, false
+// for disabled users
+const USERS_COLLECTION = "users"
+
 type UserTypes string
 
 const (
@@ -17,3 +24,9 @@ type User struct {
 	SecondLastname string `json:"second_lastname,omitempty" bson:"second_lastname" extensions:"x-omitempty" example:"Valdes"`
 	ID             string `json:"_id,omitempty" bson:"_id" extensions:"x-omitempty" example:"638660ca141aa4ee9faf07e8"`
 }
+
+type UsersModel struct{}
+
+func (users *UsersModel) Use() *mongo.Collection {
+	return DbConnect.GetCollection(USERS_COLLECTION)
+}
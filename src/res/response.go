@@ -1,5 +1,7 @@
 package res
 
+import "github.com/CPU-commits/Intranet_BNews/src/models"
+
 type Response struct {
 	Success bool                   `json:"success"`
 	Message string                 `json:"message"`
@@ -7,8 +9,9 @@ type Response struct {
 }
 
 type Notify struct {
-	Title string
-	Link  string
-	Img   string
-	Type  string
+	Title    string
+	Link     string
+	Img      string
+	Type     string
+	Audience *models.Audience
 }
@@ -4,4 +4,6 @@ const (
 	MAX_FILE_SIZE     = 52428800
 	MAX_FILE_SIZE_STR = "50MB"
 	MAX_FILES         = 3
+	// Bigger multipart files are kept in temporary files on disk
+	MAX_MULTIPART_MEMORY = 8 << 20
 )
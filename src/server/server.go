@@ -10,8 +10,10 @@ import (
 	"github.com/CPU-commits/Intranet_BNews/src/controllers"
 	"github.com/CPU-commits/Intranet_BNews/src/docs"
 	"github.com/CPU-commits/Intranet_BNews/src/middlewares"
+	"github.com/CPU-commits/Intranet_BNews/src/models"
 	"github.com/CPU-commits/Intranet_BNews/src/res"
 	"github.com/CPU-commits/Intranet_BNews/src/settings"
+	"github.com/CPU-commits/Intranet_BNews/src/storage"
 	ratelimit "github.com/JGLTechnologies/gin-rate-limit"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-contrib/secure"
@@ -46,6 +48,7 @@ func init() {
 
 func Init() {
 	router := gin.New()
+	router.MaxMultipartMemory = MAX_MULTIPART_MEMORY
 	// Proxies
 	router.SetTrustedProxies([]string{"localhost"})
 	// Zap logger
@@ -101,6 +104,7 @@ func Init() {
 			AllowCredentials: true,
 			AllowWebSockets:  false,
 			AllowHeaders:     []string{"*"},
+			ExposeHeaders:    []string{"ETag"},
 			MaxAge:           12 * time.Hour,
 		}))
 	} else {
@@ -139,6 +143,9 @@ func Init() {
 	})
 	router.Use(mw)
 	// Routes
+	// Files of the local storage, authorized by the signature of the URL
+	filesController := new(controllers.FilesController)
+	router.GET(storage.LOCAL_FILES_ROUTE+"/*key", filesController.GetFile)
 	news := router.Group(
 		"/api/news",
 		middlewares.JWTMiddleware(),
@@ -152,25 +159,135 @@ func Init() {
 	{
 		// Init controllers
 		newsController := new(controllers.NewsController)
+		commentsController := new(controllers.CommentsController)
+		categoriesController := new(controllers.CategoriesController)
+		revisionsController := new(controllers.RevisionsController)
+		uploadsController := new(controllers.UploadsController)
+		mediaController := new(controllers.MediaController)
 		// Define routes
 		news.GET("/get_news", newsController.GetNews)
 		news.GET("/get_single_news/:slug", newsController.GetSingleNews)
+		news.GET("/get_archived_news", newsController.GetArchivedNews)
+		news.GET("/search", newsController.SearchNews)
 		news.POST(
 			"/new_news",
-			middlewares.RolesMiddleware(),
+			middlewares.RolesMiddleware(models.TEACHER),
 			newsController.NewNews,
 		)
 		news.POST("/like_news/:idNews", newsController.LikeNews)
+		news.GET("/get_reactions", newsController.GetReactions)
+		news.POST("/read_news/:idNews", newsController.MarkAsRead)
+		news.POST("/read_all_news", newsController.MarkAllAsRead)
+		news.GET("/unread_count", newsController.GetUnreadCount)
+		news.POST("/acknowledge_news/:idNews", newsController.AcknowledgeNews)
+		news.GET(
+			"/get_acknowledgements/:idNews",
+			middlewares.RolesMiddleware(),
+			newsController.GetAcknowledgements,
+		)
+		news.POST("/react_news/:idNews", newsController.ReactNews)
+		news.DELETE("/react_news/:idNews", newsController.ClearReaction)
 		news.PUT(
 			"/update_news/:idNews",
-			middlewares.RolesMiddleware(),
+			middlewares.RolesMiddleware(models.TEACHER),
 			newsController.UpdateNews,
 		)
+		news.POST(
+			"/schedule_news/:idNews",
+			middlewares.RolesMiddleware(models.TEACHER),
+			newsController.ScheduleNews,
+		)
+		news.POST(
+			"/publish_news/:idNews",
+			middlewares.RolesMiddleware(models.TEACHER),
+			newsController.PublishNews,
+		)
 		news.DELETE(
 			"/delete_news/:idNews",
-			middlewares.RolesMiddleware(),
+			middlewares.RolesMiddleware(models.TEACHER),
 			newsController.DeleteNews,
 		)
+		news.GET(
+			"/get_trash",
+			middlewares.RolesMiddleware(models.TEACHER),
+			newsController.GetTrash,
+		)
+		news.POST(
+			"/restore_news/:idNews",
+			middlewares.RolesMiddleware(models.TEACHER),
+			newsController.RestoreNews,
+		)
+		// Direct uploads
+		news.POST(
+			"/upload_url",
+			middlewares.RolesMiddleware(models.TEACHER),
+			uploadsController.GetUploadURL,
+		)
+		news.POST(
+			"/confirm_upload/:idNews",
+			middlewares.RolesMiddleware(models.TEACHER),
+			uploadsController.ConfirmUpload,
+		)
+		// Media library
+		news.GET(
+			"/get_media",
+			middlewares.RolesMiddleware(models.TEACHER),
+			mediaController.GetMedia,
+		)
+		// Revisions
+		news.GET(
+			"/get_revisions/:idNews",
+			middlewares.RolesMiddleware(models.TEACHER),
+			revisionsController.GetRevisions,
+		)
+		news.GET(
+			"/get_revision/:idRevision",
+			middlewares.RolesMiddleware(models.TEACHER),
+			revisionsController.GetRevision,
+		)
+		news.GET(
+			"/diff_revisions",
+			middlewares.RolesMiddleware(models.TEACHER),
+			revisionsController.DiffRevisions,
+		)
+		news.POST(
+			"/restore_revision/:idRevision",
+			middlewares.RolesMiddleware(models.TEACHER),
+			revisionsController.RestoreRevision,
+		)
+		// Comments
+		news.GET("/get_comments/:idNews", commentsController.GetComments)
+		news.POST("/new_comment/:idNews", commentsController.NewComment)
+		news.PUT("/update_comment/:idComment", commentsController.UpdateComment)
+		news.DELETE("/delete_comment/:idComment", commentsController.DeleteComment)
+		news.POST(
+			"/hide_comment/:idComment",
+			middlewares.RolesMiddleware(models.TEACHER),
+			commentsController.HideComment,
+		)
+		news.POST(
+			"/toggle_comments/:idNews",
+			middlewares.RolesMiddleware(models.TEACHER),
+			commentsController.ToggleComments,
+		)
+		// Categories
+		news.GET("/get_categories", categoriesController.GetCategories)
+		news.GET("/get_tags", categoriesController.GetTags)
+		news.POST(
+			"/new_category",
+			middlewares.RolesMiddleware(),
+			categoriesController.NewCategory,
+		)
+		news.PUT(
+			"/update_category/:idCategory",
+			middlewares.RolesMiddleware(),
+			categoriesController.UpdateCategory,
+		)
+		news.DELETE(
+			"/delete_category/:idCategory",
+			middlewares.RolesMiddleware(),
+			categoriesController.DeleteCategory,
+		)
 	}
 	// Route docs
 	router.GET("/api/news/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -0,0 +1,529 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"github.com/CPU-commits/Intranet_BNews/src/models"
+	"github.com/CPU-commits/Intranet_BNews/src/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var acknowledgementsService *AcknowledgementsService
+
+type AcknowledgementsService struct{}
+
+func (a *AcknowledgementsService) AcknowledgeNews(idNews string, claims *Claims) *ErrorRes {
+	newsObjectId, userObjectID, errRes := getVisibleNewsIds(idNews, claims)
+	if errRes != nil {
+		return errRes
+	}
+	var newsData *models.News
+	err := newsModel.Use().FindOne(db.Ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: newsObjectId,
+		},
+		{
+			Key:   "requires_ack",
+			Value: true,
+		},
+	}).Decode(&newsData)
+	if err != nil {
+		return &ErrorRes{
+			Err:        fmt.Errorf("esta noticia no requiere confirmación de lectura"),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	acknowledgement := acknowledgementsModel.NewModel(userObjectID, newsObjectId)
+	opts := options.Update().SetUpsert(true)
+	_, err = acknowledgementsModel.Use().UpdateOne(
+		db.Ctx,
+		bson.D{
+			{
+				Key:   "news",
+				Value: newsObjectId,
+			},
+			{
+				Key:   "user",
+				Value: userObjectID,
+			},
+		},
+		bson.D{
+			{
+				Key:   "$setOnInsert",
+				Value: acknowledgement,
+			},
+		},
+		opts,
+	)
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	// Acknowledged news are read
+	readsService.markRead(newsObjectId, userObjectID)
+	invalidateUserNewsList(userObjectID)
+	return nil
+}
+
+// The users collection does not know the courses of a user, the users of
+// news to courses or levels are requested to the users service. Returns
+// nil without courses and levels, and false if they could not be resolved
+func (a *AcknowledgementsService) getAudienceUsers(newsData *models.News) ([]primitive.ObjectID, bool) {
+	if newsData.Audience == nil || (len(newsData.Audience.Courses) == 0 && len(newsData.Audience.Levels) == 0) {
+		return nil, true
+	}
+	users, err := requestAudienceUsers(newsData.Audience)
+	if err != nil {
+		log.Printf("Error getting users of the audience of news %s: %v\n", newsData.ID.Hex(), err)
+		return nil, false
+	}
+	return users, true
+}
+
+// Users targeted by the audience of a news that did not acknowledge it.
+// Users are matched by their type, the same of the JWT, and by the users
+// of the courses and levels. If those could not be resolved, the pending
+// users are only the ones that read the news without confirming
+func (a *AcknowledgementsService) getPendingPipeline(
+	newsData *models.News,
+	users []primitive.ObjectID,
+	resolved bool,
+) mongo.Pipeline {
+	roles := []string{
+		models.DIRECTOR,
+		models.DIRECTIVE,
+		models.TEACHER,
+		models.ATTORNEY,
+		models.STUDENT_DIRECTIVE,
+		models.STUDENT,
+	}
+	if newsData.Type == "student" {
+		roles = []string{models.STUDENT_DIRECTIVE, models.STUDENT}
+	}
+	if newsData.Audience != nil && len(newsData.Audience.Roles) > 0 {
+		roles = newsData.Audience.Roles
+	}
+	pipeline := mongo.Pipeline{
+		bson.D{
+			{
+				Key: "$match",
+				Value: bson.M{
+					"user_type": bson.M{
+						"$in": roles,
+					},
+					"status": bson.M{
+						"$ne": false,
+					},
+				},
+			},
+		},
+	}
+	if users != nil {
+		pipeline = append(pipeline, bson.D{
+			{
+				Key: "$match",
+				Value: bson.M{
+					"_id": bson.M{
+						"$in": users,
+					},
+				},
+			},
+		})
+	}
+	if !resolved {
+		pipeline = append(
+			pipeline,
+			bson.D{
+				{
+					Key: "$lookup",
+					Value: bson.M{
+						"from":         models.READS_COLLECTION,
+						"localField":   "_id",
+						"foreignField": "user",
+						"as":           "read",
+						"pipeline": bson.A{
+							bson.M{
+								"$match": bson.M{
+									"news": newsData.ID,
+								},
+							},
+						},
+					},
+				},
+			},
+			bson.D{
+				{
+					Key: "$match",
+					Value: bson.M{
+						"read.0": bson.M{
+							"$exists": true,
+						},
+					},
+				},
+			},
+		)
+	}
+	return append(
+		pipeline,
+		bson.D{
+			{
+				Key: "$lookup",
+				Value: bson.M{
+					"from":         models.ACKNOWLEDGEMENTS_COLLECTION,
+					"localField":   "_id",
+					"foreignField": "user",
+					"as":           "acknowledgement",
+					"pipeline": bson.A{
+						bson.M{
+							"$match": bson.M{
+								"news": newsData.ID,
+							},
+						},
+					},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$match",
+				Value: bson.M{
+					"acknowledgement": bson.M{
+						"$size": 0,
+					},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$sort",
+				Value: bson.D{
+					{Key: "first_lastname", Value: 1},
+					{Key: "name", Value: 1},
+					{Key: "_id", Value: 1},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$project",
+				Value: bson.M{
+					"name":            1,
+					"first_lastname":  1,
+					"second_lastname": 1,
+				},
+			},
+		},
+	)
+}
+
+func (a *AcknowledgementsService) getAcknowledgedPipeline(newsObjectId primitive.ObjectID) mongo.Pipeline {
+	return mongo.Pipeline{
+		bson.D{
+			{
+				Key: "$match",
+				Value: bson.M{
+					"news": newsObjectId,
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$sort",
+				Value: bson.D{
+					{Key: "date", Value: 1},
+					{Key: "_id", Value: 1},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$lookup",
+				Value: bson.M{
+					"from":         "users",
+					"localField":   "user",
+					"foreignField": "_id",
+					"as":           "user",
+					"pipeline": bson.A{
+						bson.M{
+							"$project": bson.M{
+								"name":            1,
+								"first_lastname":  1,
+								"second_lastname": 1,
+							},
+						},
+					},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$project",
+				Value: bson.M{
+					"date": 1,
+					"user": bson.M{
+						"$arrayElemAt": bson.A{"$user", 0},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Page of the results of a pipeline, with the total of results
+func (a *AcknowledgementsService) getPage(
+	collection *mongo.Collection,
+	pipeline mongo.Pipeline,
+	skip int,
+	limit int,
+	results interface{},
+) (int, error) {
+	page := append(mongo.Pipeline{}, pipeline...)
+	page = append(
+		page,
+		bson.D{
+			{
+				Key:   "$skip",
+				Value: skip,
+			},
+		},
+		bson.D{
+			{
+				Key:   "$limit",
+				Value: limit,
+			},
+		},
+	)
+	cursor, err := collection.Aggregate(db.Ctx, page)
+	if err != nil {
+		return 0, err
+	}
+	if err = cursor.All(db.Ctx, results); err != nil {
+		return 0, err
+	}
+	count := append(mongo.Pipeline{}, pipeline...)
+	count = append(count, bson.D{
+		{
+			Key:   "$count",
+			Value: "total",
+		},
+	})
+	cursor, err = collection.Aggregate(db.Ctx, count)
+	if err != nil {
+		return 0, err
+	}
+	var total []struct {
+		Total int `bson:"total"`
+	}
+	if err = cursor.All(db.Ctx, &total); err != nil {
+		return 0, err
+	}
+	if len(total) == 0 {
+		return 0, nil
+	}
+	return total[0].Total, nil
+}
+
+func (a *AcknowledgementsService) getNewsToReport(idNews string, claims *Claims) (*models.News, *ErrorRes) {
+	if claims.UserType != models.DIRECTOR && claims.UserType != models.DIRECTIVE {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("Unauthorized"),
+			StatusCode: http.StatusUnauthorized,
+		}
+	}
+	newsObjectId, err := primitive.ObjectIDFromHex(idNews)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("noticia no encontrada"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	var newsData *models.News
+	err = newsModel.Use().FindOne(db.Ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: newsObjectId,
+		},
+		{
+			Key:   "requires_ack",
+			Value: true,
+		},
+	}).Decode(&newsData)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("noticia no encontrada"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	return newsData, nil
+}
+
+// Both lists are paginated with the same skip and limit
+func (a *AcknowledgementsService) GetAcknowledgements(
+	idNews string,
+	skip string,
+	limit string,
+	claims *Claims,
+) (*AcknowledgementsReport, *ErrorRes) {
+	skipNumber, limitNumber, err := utils.ParseSkipLimit(skip, limit)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	newsData, errRes := a.getNewsToReport(idNews, claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	report := &AcknowledgementsReport{
+		Acknowledged: []AcknowledgementResponse{},
+		Pending:      []models.User{},
+	}
+	report.TotalAcknowledged, err = a.getPage(
+		acknowledgementsModel.Use(),
+		a.getAcknowledgedPipeline(newsData.ID),
+		skipNumber,
+		limitNumber,
+		&report.Acknowledged,
+	)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	users, resolved := a.getAudienceUsers(newsData)
+	report.PendingPartial = !resolved
+	report.TotalPending, err = a.getPage(
+		usersModel.Use(),
+		a.getPendingPipeline(newsData, users, resolved),
+		skipNumber,
+		limitNumber,
+		&report.Pending,
+	)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return report, nil
+}
+
+// Whole report, rows are written as they are read
+type AcknowledgementsExport struct {
+	acknowledged *mongo.Cursor
+	pending      *mongo.Cursor
+}
+
+func (a *AcknowledgementsService) ExportAcknowledgements(
+	idNews string,
+	claims *Claims,
+) (*AcknowledgementsExport, *ErrorRes) {
+	newsData, errRes := a.getNewsToReport(idNews, claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	// The export is a whole report, it is not written without every user
+	users, resolved := a.getAudienceUsers(newsData)
+	if !resolved {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("no se pudieron obtener los usuarios de la audiencia, intenta más tarde"),
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	acknowledged, err := acknowledgementsModel.Use().Aggregate(
+		db.Ctx,
+		a.getAcknowledgedPipeline(newsData.ID),
+	)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	pending, err := usersModel.Use().Aggregate(db.Ctx, a.getPendingPipeline(newsData, users, resolved))
+	if err != nil {
+		acknowledged.Close(db.Ctx)
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return &AcknowledgementsExport{
+		acknowledged: acknowledged,
+		pending:      pending,
+	}, nil
+}
+
+func (export *AcknowledgementsExport) WriteCSV(w io.Writer) error {
+	defer export.acknowledged.Close(db.Ctx)
+	defer export.pending.Close(db.Ctx)
+
+	writer := csv.NewWriter(w)
+	write := func(record ...string) {
+		for i := range record {
+			record[i] = utils.EscapeCSVCell(record[i])
+		}
+		writer.Write(record)
+	}
+	write(
+		"Nombre",
+		"Primer apellido",
+		"Segundo apellido",
+		"Estado",
+		"Fecha",
+	)
+	for export.acknowledged.Next(db.Ctx) {
+		var acknowledgement AcknowledgementResponse
+		if err := export.acknowledged.Decode(&acknowledgement); err != nil {
+			return err
+		}
+		write(
+			acknowledgement.User.Name,
+			acknowledgement.User.FirstLastname,
+			acknowledgement.User.SecondLastname,
+			"Confirmado",
+			acknowledgement.Date.Time().Format("2006-01-02 15:04:05"),
+		)
+	}
+	if err := export.acknowledged.Err(); err != nil {
+		return err
+	}
+	for export.pending.Next(db.Ctx) {
+		var user models.User
+		if err := export.pending.Decode(&user); err != nil {
+			return err
+		}
+		write(
+			user.Name,
+			user.FirstLastname,
+			user.SecondLastname,
+			"Pendiente",
+			"",
+		)
+	}
+	if err := export.pending.Err(); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func NewAcknowledgementsService() *AcknowledgementsService {
+	if acknowledgementsService == nil {
+		acknowledgementsService = &AcknowledgementsService{}
+	}
+	return acknowledgementsService
+}
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"github.com/CPU-commits/Intranet_BNews/src/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Time to wait the courses and levels of a user
+const USER_AUDIENCE_TIMEOUT = 2 * time.Second
+
+// User reading news, with the courses and levels that concern them.
+// Students belong to their course, attorneys to the courses of their
+// children and teachers to the courses they teach
+type Reader struct {
+	ID       primitive.ObjectID   `bson:"_id"`
+	UserType string               `bson:"user_type"`
+	Courses  []primitive.ObjectID `bson:"courses"`
+	Levels   []primitive.ObjectID `bson:"levels"`
+	// The courses and levels could not be resolved, the reader only
+	// sees news without courses and levels
+	partial bool
+}
+
+// Reply of the get_user_audience subject, answered by the users service
+// of the intranet. The request is {"user": "<MongoID>", "user_type": "<type>"}
+type userAudienceNats struct {
+	Courses []string `json:"courses"`
+	Levels  []string `json:"levels"`
+}
+
+func requestUserAudience(claims *Claims) (*models.Audience, error) {
+	data, err := json.Marshal(map[string]string{
+		"user":      claims.ID,
+		"user_type": claims.UserType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	msg, err := nats.RequestTimeout("get_user_audience", data, USER_AUDIENCE_TIMEOUT)
+	if err != nil {
+		return nil, err
+	}
+	var userAudience userAudienceNats
+	if err := json.Unmarshal(msg.Data, &userAudience); err != nil {
+		return nil, err
+	}
+	return models.NewAudience(nil, userAudience.Courses, userAudience.Levels)
+}
+
+// Reply of the get_audience_users subject, answered by the users service
+// of the intranet. The request is {"courses": ["<MongoID>"], "levels": ["<MongoID>"]}
+// and the reply has the students of the courses and levels, their
+// attorneys and their teachers
+type audienceUsersNats struct {
+	Users []string `json:"users"`
+}
+
+func requestAudienceUsers(audience *models.Audience) ([]primitive.ObjectID, error) {
+	data, err := json.Marshal(map[string][]primitive.ObjectID{
+		"courses": audience.Courses,
+		"levels":  audience.Levels,
+	})
+	if err != nil {
+		return nil, err
+	}
+	msg, err := nats.RequestTimeout("get_audience_users", data, USER_AUDIENCE_TIMEOUT)
+	if err != nil {
+		return nil, err
+	}
+	var audienceUsers audienceUsersNats
+	if err := json.Unmarshal(msg.Data, &audienceUsers); err != nil {
+		return nil, err
+	}
+	users := []primitive.ObjectID{}
+	for _, user := range audienceUsers.Users {
+		userObjectID, err := primitive.ObjectIDFromHex(user)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, userObjectID)
+	}
+	return users, nil
+}
+
+// The read path does not depend on the users service, if it does not
+// answer the reader falls back to the news of the whole school
+func getReader(claims *Claims) (*Reader, *ErrorRes) {
+	userObjectID, err := primitive.ObjectIDFromHex(claims.ID)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	reader := &Reader{
+		ID:       userObjectID,
+		UserType: claims.UserType,
+		Courses:  []primitive.ObjectID{},
+		Levels:   []primitive.ObjectID{},
+	}
+	if reader.canManage() {
+		return reader, nil
+	}
+	if cached, ok := getCachedReader(userObjectID, claims.UserType); ok {
+		return cached, nil
+	}
+	// Request NATS (Courses and levels of the user)
+	audience, err := requestUserAudience(claims)
+	if err != nil {
+		log.Printf("Error getting audience of user %s: %v\n", claims.ID, err)
+		reader.partial = true
+		return reader, nil
+	}
+	if audience != nil {
+		reader.Courses = audience.Courses
+		reader.Levels = audience.Levels
+	}
+	// Partial readers are not cached, the next request tries again
+	setCachedReader(reader)
+	return reader, nil
+}
+
+// Directors and directives see every news
+func (r *Reader) canManage() bool {
+	return r.UserType == models.DIRECTOR || r.UserType == models.DIRECTIVE
+}
+
+func (r *Reader) canSeeType(newsType string) bool {
+	if newsType == "student" {
+		return r.UserType == models.STUDENT || r.UserType == models.STUDENT_DIRECTIVE
+	}
+	return true
+}
+
+func (r *Reader) getNewsTypes() bson.A {
+	if r.canSeeType("student") {
+		return bson.A{"global", "student"}
+	}
+	return bson.A{"global"}
+}
+
+func (r *Reader) hasCourse(course primitive.ObjectID) bool {
+	for _, readerCourse := range r.Courses {
+		if readerCourse == course {
+			return true
+		}
+	}
+	return false
+}
+
+// News whose audience includes the reader, every dimension of the
+// audience must match. Authors always see their news
+func (r *Reader) getFilterAudience() bson.M {
+	if r.canManage() {
+		return bson.M{}
+	}
+	return bson.M{
+		"$or": bson.A{
+			bson.M{
+				"author_id": r.ID,
+			},
+			bson.M{
+				"$and": bson.A{
+					bson.M{
+						"$or": bson.A{
+							bson.M{"audience.roles.0": bson.M{"$exists": false}},
+							bson.M{"audience.roles": r.UserType},
+						},
+					},
+					bson.M{
+						"$or": bson.A{
+							bson.M{"audience.courses.0": bson.M{"$exists": false}},
+							bson.M{"audience.courses": bson.M{"$in": r.Courses}},
+						},
+					},
+					bson.M{
+						"$or": bson.A{
+							bson.M{"audience.levels.0": bson.M{"$exists": false}},
+							bson.M{"audience.levels": bson.M{"$in": r.Levels}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Teachers only publish to the courses they teach
+func (r *Reader) verifyAudience(audience *models.Audience) *ErrorRes {
+	if r.UserType != models.TEACHER {
+		return nil
+	}
+	if r.partial {
+		return &ErrorRes{
+			Err:        fmt.Errorf("no se pudieron obtener tus cursos, intenta más tarde"),
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	if audience == nil || len(audience.Courses) == 0 {
+		return &ErrorRes{
+			Err:        fmt.Errorf("debes indicar los cursos de la noticia"),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	for _, course := range audience.Courses {
+		if !r.hasCourse(course) {
+			return &ErrorRes{
+				Err:        fmt.Errorf("solo puedes publicar noticias a tus cursos"),
+				StatusCode: http.StatusForbidden,
+			}
+		}
+	}
+	return nil
+}
+
+// Ids of a published news visible to the user, and of the user
+func getVisibleNewsIds(idNews string, claims *Claims) (primitive.ObjectID, primitive.ObjectID, *ErrorRes) {
+	newsObjectId, err := primitive.ObjectIDFromHex(idNews)
+	if err != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, &ErrorRes{
+			StatusCode: http.StatusBadRequest,
+			Err:        err,
+		}
+	}
+	reader, errRes := getReader(claims)
+	if errRes != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, errRes
+	}
+
+	var newsData *models.News
+	opts := options.FindOne().SetProjection(bson.D{
+		{
+			Key:   "_id",
+			Value: 1,
+		},
+	})
+	cursor := newsModel.Use().FindOne(db.Ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: newsObjectId,
+		},
+		{
+			Key:   "status",
+			Value: true,
+		},
+		{
+			Key:   "state",
+			Value: models.NEWS_STATE_PUBLISHED,
+		},
+		{
+			Key: "type",
+			Value: bson.M{
+				"$in": reader.getNewsTypes(),
+			},
+		},
+		{
+			Key: "$and",
+			Value: bson.A{
+				reader.getFilterAudience(),
+			},
+		},
+	}, opts)
+	cursor.Decode(&newsData)
+	if newsData == nil {
+		return primitive.NilObjectID, primitive.NilObjectID, &ErrorRes{
+			Err:        fmt.Errorf("Noticia no encontrada"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	return newsObjectId, reader.ID, nil
+}
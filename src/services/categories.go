@@ -0,0 +1,236 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"github.com/CPU-commits/Intranet_BNews/src/forms"
+	"github.com/CPU-commits/Intranet_BNews/src/models"
+	"github.com/CPU-commits/Intranet_BNews/src/utils"
+	"github.com/gosimple/slug"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var categoriesService *CategoriesService
+
+type CategoriesService struct{}
+
+// Category of a news must exist and be active
+func (c *CategoriesService) getCategory(idCategory string) (*models.Category, *ErrorRes) {
+	categoryObjectId, err := primitive.ObjectIDFromHex(idCategory)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("categoría no encontrada"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	var category *models.Category
+	err = categoriesModel.Use().FindOne(db.Ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: categoryObjectId,
+		},
+		{
+			Key:   "status",
+			Value: true,
+		},
+	}).Decode(&category)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("categoría no encontrada"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	return category, nil
+}
+
+func (c *CategoriesService) GetCategories() ([]models.Category, *ErrorRes) {
+	opts := options.Find().SetSort(bson.D{
+		{Key: "name", Value: 1},
+	})
+	cursor, err := categoriesModel.Use().Find(db.Ctx, bson.D{
+		{
+			Key:   "status",
+			Value: true,
+		},
+	}, opts)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	categories := []models.Category{}
+	if err = cursor.All(db.Ctx, &categories); err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return categories, nil
+}
+
+func (c *CategoriesService) NewCategory(data forms.CategoryDTO) (primitive.ObjectID, *ErrorRes) {
+	category := categoriesModel.NewModel(data.Name)
+	inserted, err := categoriesModel.Use().InsertOne(db.Ctx, category)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return primitive.NilObjectID, &ErrorRes{
+				Err:        fmt.Errorf("la categoría ya existe"),
+				StatusCode: http.StatusConflict,
+			}
+		}
+		return primitive.NilObjectID, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return inserted.InsertedID.(primitive.ObjectID), nil
+}
+
+func (c *CategoriesService) UpdateCategory(data forms.CategoryDTO, idCategory string) *ErrorRes {
+	category, errRes := c.getCategory(idCategory)
+	if errRes != nil {
+		return errRes
+	}
+	_, err := categoriesModel.Use().UpdateByID(
+		db.Ctx,
+		category.ID,
+		bson.D{
+			{
+				Key: "$set",
+				Value: bson.M{
+					"name": data.Name,
+					"slug": slug.MakeLang(data.Name, "es"),
+				},
+			},
+		},
+	)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return &ErrorRes{
+				Err:        fmt.Errorf("la categoría ya existe"),
+				StatusCode: http.StatusConflict,
+			}
+		}
+		return &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return nil
+}
+
+// News keep their category, it is not listed nor assignable anymore
+func (c *CategoriesService) DeleteCategory(idCategory string) *ErrorRes {
+	category, errRes := c.getCategory(idCategory)
+	if errRes != nil {
+		return errRes
+	}
+	_, err := categoriesModel.Use().UpdateByID(
+		db.Ctx,
+		category.ID,
+		bson.D{
+			{
+				Key: "$set",
+				Value: bson.M{
+					"status": false,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return nil
+}
+
+// Tags of the news visible to the user with their usage
+func (c *CategoriesService) GetTags(limit string, newsType string, claims *Claims) ([]TagResponse, *ErrorRes) {
+	limitNumber, err := utils.ParseLimit(limit)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	reader, errRes := getReader(claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	// Tags of student news are only visible to students
+	if !reader.canSeeType(newsType) {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("no tienes acceso a estas noticias"),
+			StatusCode: http.StatusForbidden,
+		}
+	}
+	cursor, err := newsModel.Use().Aggregate(db.Ctx, mongo.Pipeline{
+		bson.D{
+			{
+				Key:   "$match",
+				Value: newsService.getFilterStatusTrue(newsType, reader),
+			},
+		},
+		bson.D{
+			{
+				Key:   "$unwind",
+				Value: "$tags",
+			},
+		},
+		bson.D{
+			{
+				Key: "$group",
+				Value: bson.M{
+					"_id": "$tags",
+					"count": bson.M{
+						"$sum": 1,
+					},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$sort",
+				Value: bson.D{
+					{Key: "count", Value: -1},
+					{Key: "_id", Value: 1},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key:   "$limit",
+				Value: limitNumber,
+			},
+		},
+	})
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	tags := []TagResponse{}
+	if err = cursor.All(db.Ctx, &tags); err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return tags, nil
+}
+
+func NewCategoriesService() *CategoriesService {
+	if categoriesService == nil {
+		categoriesService = &CategoriesService{}
+	}
+	return categoriesService
+}
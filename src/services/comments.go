@@ -0,0 +1,598 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"github.com/CPU-commits/Intranet_BNews/src/forms"
+	"github.com/CPU-commits/Intranet_BNews/src/models"
+	"github.com/CPU-commits/Intranet_BNews/src/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var commentsService *CommentsService
+
+type CommentsService struct{}
+
+// Those who can manage the news moderate its comments, teachers only
+// moderate the comments of their news
+func (c *CommentsService) canModerate(newsData *models.News, claims *Claims) bool {
+	return NewNewsService().verifyIdentity(newsData.Type, newsData.AuthorId.Hex(), claims) == nil
+}
+
+func (c *CommentsService) getNews(idNews string, claims *Claims) (*models.News, *ErrorRes) {
+	reader, errRes := getReader(claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	newsObjectId, err := primitive.ObjectIDFromHex(idNews)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("noticia no encontrada"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	var newsData *models.News
+	err = newsModel.Use().FindOne(db.Ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: newsObjectId,
+		},
+		{
+			Key:   "status",
+			Value: true,
+		},
+		{
+			Key:   "state",
+			Value: models.NEWS_STATE_PUBLISHED,
+		},
+		{
+			Key: "type",
+			Value: bson.M{
+				"$in": reader.getNewsTypes(),
+			},
+		},
+		{
+			Key: "$and",
+			Value: bson.A{
+				reader.getFilterAudience(),
+			},
+		},
+	}).Decode(&newsData)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("noticia no encontrada"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	return newsData, nil
+}
+
+func (c *CommentsService) getComment(idComment string) (*models.Comment, *ErrorRes) {
+	commentObjectId, err := primitive.ObjectIDFromHex(idComment)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("comentario no encontrado"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	var comment *models.Comment
+	err = commentsModel.Use().FindOne(db.Ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: commentObjectId,
+		},
+		{
+			Key:   "status",
+			Value: true,
+		},
+	}).Decode(&comment)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("comentario no encontrado"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	return comment, nil
+}
+
+func (c *CommentsService) incrementComments(newsObjectId primitive.ObjectID, increment int) error {
+	_, err := newsModel.Use().UpdateOne(
+		db.Ctx,
+		bson.D{
+			{
+				Key:   "_id",
+				Value: newsObjectId,
+			},
+		},
+		bson.D{
+			{
+				Key: "$inc",
+				Value: bson.M{
+					"comments": increment,
+				},
+			},
+			{
+				Key: "$set",
+				Value: bson.M{
+					"activity_date": primitive.NewDateTimeFromTime(time.Now()),
+				},
+			},
+		},
+	)
+	return err
+}
+
+func (c *CommentsService) GetComments(
+	idNews string,
+	parent string,
+	skip string,
+	limit string,
+	claims *Claims,
+) ([]CommentResponse, int, *ErrorRes) {
+	newsData, errRes := c.getNews(idNews, claims)
+	if errRes != nil {
+		return nil, 0, errRes
+	}
+	skipNumber, limitNumber, err := utils.ParseSkipLimit(skip, limit)
+	if err != nil {
+		return nil, 0, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	// Top level comments or replies of a comment
+	filter := bson.M{
+		"news":   newsData.ID,
+		"status": true,
+		"parent": bson.M{
+			"$exists": false,
+		},
+	}
+	if parent != "" {
+		parentObjectId, err := primitive.ObjectIDFromHex(parent)
+		if err != nil {
+			return nil, 0, &ErrorRes{
+				Err:        err,
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+		filter["parent"] = parentObjectId
+	}
+	// Moderators also see hidden comments
+	moderator := c.canModerate(newsData, claims)
+	if !moderator {
+		filter["hidden"] = false
+	}
+	repliesMatch := bson.M{
+		"$expr": bson.M{
+			"$eq": bson.A{"$parent", "$$comment"},
+		},
+		"status": true,
+	}
+	if !moderator {
+		repliesMatch["hidden"] = false
+	}
+	cursor, err := commentsModel.Use().Aggregate(db.Ctx, mongo.Pipeline{
+		bson.D{
+			{
+				Key:   "$match",
+				Value: filter,
+			},
+		},
+		bson.D{
+			{
+				Key: "$sort",
+				Value: bson.D{
+					{Key: "upload_date", Value: 1},
+					{Key: "_id", Value: 1},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key:   "$skip",
+				Value: skipNumber,
+			},
+		},
+		bson.D{
+			{
+				Key:   "$limit",
+				Value: limitNumber,
+			},
+		},
+		bson.D{
+			{
+				Key: "$lookup",
+				Value: bson.M{
+					"from":         "users",
+					"localField":   "user",
+					"foreignField": "_id",
+					"as":           "author",
+					"pipeline": bson.A{
+						bson.M{
+							"$project": bson.M{
+								"name":            1,
+								"first_lastname":  1,
+								"second_lastname": 1,
+							},
+						},
+					},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$lookup",
+				Value: bson.M{
+					"from": models.COMMENTS_COLLECTION,
+					"let": bson.M{
+						"comment": "$_id",
+					},
+					"as": "replies",
+					"pipeline": bson.A{
+						bson.M{
+							"$match": repliesMatch,
+						},
+						bson.M{
+							"$count": "count",
+						},
+					},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$project",
+				Value: bson.M{
+					"parent":      1,
+					"body":        1,
+					"hidden":      1,
+					"upload_date": 1,
+					"update_date": 1,
+					"author": bson.M{
+						"$arrayElemAt": bson.A{
+							"$author", 0,
+						},
+					},
+					"replies": bson.M{
+						"$ifNull": bson.A{
+							bson.M{
+								"$arrayElemAt": bson.A{"$replies.count", 0},
+							},
+							0,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, 0, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	var comments []CommentResponse
+	if err := cursor.All(db.Ctx, &comments); err != nil {
+		return nil, 0, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	total, err := commentsModel.Use().CountDocuments(db.Ctx, filter)
+	if err != nil {
+		return nil, 0, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return comments, int(total), nil
+}
+
+func (c *CommentsService) NewComment(
+	data forms.CommentDTO,
+	idNews string,
+	claims *Claims,
+) (primitive.ObjectID, *ErrorRes) {
+	newsData, errRes := c.getNews(idNews, claims)
+	if errRes != nil {
+		return primitive.NilObjectID, errRes
+	}
+	if newsData.CommentsDisabled {
+		return primitive.NilObjectID, &ErrorRes{
+			Err:        fmt.Errorf("los comentarios de esta noticia están desactivados"),
+			StatusCode: http.StatusForbidden,
+		}
+	}
+	userObjectID, err := primitive.ObjectIDFromHex(claims.ID)
+	if err != nil {
+		return primitive.NilObjectID, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	// Reply
+	var parentObjectId primitive.ObjectID
+	if data.Parent != "" {
+		parent, errRes := c.getComment(data.Parent)
+		if errRes != nil {
+			return primitive.NilObjectID, errRes
+		}
+		if parent.NewsID != newsData.ID {
+			return primitive.NilObjectID, &ErrorRes{
+				Err:        fmt.Errorf("el comentario no pertenece a esta noticia"),
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+		parentObjectId = parent.ID
+	}
+	comment := commentsModel.NewModel(newsData.ID, userObjectID, parentObjectId, data.Body)
+	inserted, err := commentsModel.Use().InsertOne(db.Ctx, comment)
+	if err != nil {
+		return primitive.NilObjectID, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	if err := c.incrementComments(newsData.ID, 1); err != nil {
+		return primitive.NilObjectID, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return inserted.InsertedID.(primitive.ObjectID), nil
+}
+
+func (c *CommentsService) UpdateComment(
+	data forms.UpdateCommentDTO,
+	idComment string,
+	claims *Claims,
+) *ErrorRes {
+	comment, errRes := c.getComment(idComment)
+	if errRes != nil {
+		return errRes
+	}
+	if comment.UserID.Hex() != claims.ID {
+		return &ErrorRes{
+			Err:        fmt.Errorf("Unauthorized"),
+			StatusCode: http.StatusUnauthorized,
+		}
+	}
+	_, err := commentsModel.Use().UpdateOne(
+		db.Ctx,
+		bson.D{
+			{
+				Key:   "_id",
+				Value: comment.ID,
+			},
+		},
+		bson.D{
+			{
+				Key: "$set",
+				Value: bson.M{
+					"body":        data.Body,
+					"update_date": primitive.NewDateTimeFromTime(time.Now()),
+				},
+			},
+		},
+	)
+	if err != nil {
+		return &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return nil
+}
+
+func (c *CommentsService) DeleteComment(idComment string, claims *Claims) *ErrorRes {
+	comment, errRes := c.getComment(idComment)
+	if errRes != nil {
+		return errRes
+	}
+	if comment.UserID.Hex() != claims.ID {
+		return &ErrorRes{
+			Err:        fmt.Errorf("Unauthorized"),
+			StatusCode: http.StatusUnauthorized,
+		}
+	}
+	result, err := commentsModel.Use().UpdateOne(
+		db.Ctx,
+		bson.D{
+			{
+				Key:   "_id",
+				Value: comment.ID,
+			},
+			{
+				Key:   "status",
+				Value: true,
+			},
+		},
+		bson.D{
+			{
+				Key: "$set",
+				Value: bson.M{
+					"status":      false,
+					"update_date": primitive.NewDateTimeFromTime(time.Now()),
+				},
+			},
+		},
+	)
+	if err != nil {
+		return &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	if result.ModifiedCount == 0 {
+		return nil
+	}
+	// Replies can not be reached without their comment, they are deleted too
+	replies, err := c.deleteReplies(comment.ID)
+	if err != nil {
+		return &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	// Hidden comments are already out of the counter
+	deleted := replies
+	if !comment.Hidden {
+		deleted++
+	}
+	if deleted > 0 {
+		if err := c.incrementComments(comment.NewsID, -deleted); err != nil {
+			return &ErrorRes{
+				Err:        err,
+				StatusCode: http.StatusServiceUnavailable,
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the deleted replies that were visible
+func (c *CommentsService) deleteReplies(commentObjectId primitive.ObjectID) (int, error) {
+	update := bson.D{
+		{
+			Key: "$set",
+			Value: bson.M{
+				"status":      false,
+				"update_date": primitive.NewDateTimeFromTime(time.Now()),
+			},
+		},
+	}
+	var visible int
+	for _, hidden := range []bool{false, true} {
+		result, err := commentsModel.Use().UpdateMany(
+			db.Ctx,
+			bson.D{
+				{
+					Key:   "parent",
+					Value: commentObjectId,
+				},
+				{
+					Key:   "status",
+					Value: true,
+				},
+				{
+					Key:   "hidden",
+					Value: hidden,
+				},
+			},
+			update,
+		)
+		if err != nil {
+			return 0, err
+		}
+		if !hidden {
+			visible = int(result.ModifiedCount)
+		}
+	}
+	return visible, nil
+}
+
+// Toggle the visibility of a comment, only for moderators
+func (c *CommentsService) HideComment(idComment string, claims *Claims) (bool, *ErrorRes) {
+	comment, errRes := c.getComment(idComment)
+	if errRes != nil {
+		return false, errRes
+	}
+	if _, errRes := NewNewsService().getNewsToManage(comment.NewsID.Hex(), claims); errRes != nil {
+		return false, errRes
+	}
+	hidden := !comment.Hidden
+	result, err := commentsModel.Use().UpdateOne(
+		db.Ctx,
+		bson.D{
+			{
+				Key:   "_id",
+				Value: comment.ID,
+			},
+			{
+				Key:   "hidden",
+				Value: comment.Hidden,
+			},
+		},
+		bson.D{
+			{
+				Key: "$set",
+				Value: bson.M{
+					"hidden": hidden,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return false, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	if result.ModifiedCount == 1 {
+		increment := 1
+		if hidden {
+			increment = -1
+		}
+		if err := c.incrementComments(comment.NewsID, increment); err != nil {
+			return false, &ErrorRes{
+				Err:        err,
+				StatusCode: http.StatusServiceUnavailable,
+			}
+		}
+	}
+	return hidden, nil
+}
+
+// Toggle the comments of a news, for those who can manage the news
+func (c *CommentsService) ToggleComments(idNews string, claims *Claims) (bool, *ErrorRes) {
+	newsData, errRes := NewNewsService().getNewsToManage(idNews, claims)
+	if errRes != nil {
+		return false, errRes
+	}
+	disabled := !newsData.CommentsDisabled
+	_, err := newsModel.Use().UpdateOne(
+		db.Ctx,
+		bson.D{
+			{
+				Key:   "_id",
+				Value: newsData.ID,
+			},
+		},
+		bson.D{
+			{
+				Key: "$set",
+				Value: bson.M{
+					"comments_disabled": disabled,
+					"activity_date":     primitive.NewDateTimeFromTime(time.Now()),
+				},
+			},
+			{
+				Key: "$inc",
+				Value: bson.M{
+					"version": 1,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return false, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	invalidateNewsList()
+	return disabled, nil
+}
+
+func NewCommentsService() *CommentsService {
+	if commentsService == nil {
+		commentsService = &CommentsService{}
+	}
+	return commentsService
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/CPU-commits/Intranet_BNews/src/storage"
+)
+
+var filesService *FilesService
+
+type FilesService struct{}
+
+// Files of storages that sign their URLs in this service
+func (f *FilesService) GetSignedFile(
+	key string,
+	expires string,
+	signature string,
+) (io.ReadCloser, *storage.FileInfo, *ErrorRes) {
+	verifier, ok := fileStorage.(storage.URLVerifier)
+	if !ok {
+		return nil, nil, &ErrorRes{
+			Err:        storage.ErrNotSupported,
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	if !verifier.Verify(key, expires, signature) {
+		return nil, nil, &ErrorRes{
+			Err:        fmt.Errorf("el enlace del archivo no es válido o expiró"),
+			StatusCode: http.StatusForbidden,
+		}
+	}
+	file, info, err := fileStorage.Get(key)
+	if err != nil {
+		return nil, nil, &ErrorRes{
+			Err:        fmt.Errorf("no existe el archivo"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	return file, info, nil
+}
+
+func NewFilesService() *FilesService {
+	if filesService == nil {
+		filesService = &FilesService{}
+	}
+	return filesService
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/settings"
+)
+
+// Strategies to sign image URLs, selected by the IMAGE_URL_STRATEGY env.
+// Local signs with the S3 session, NATS delegates to the files service
+const (
+	IMAGE_URL_LOCAL = "local"
+	IMAGE_URL_NATS  = "nats"
+)
+
+// Validity of signed image URLs, overridable by the IMAGE_URL_MINUTES env.
+// With the NATS strategy it must match the validity of the files service
+const DEFAULT_IMAGE_URL_MINUTES = 10
+
+var imageURLExpiry = getImageURLExpiry()
+
+func getImageURLExpiry() time.Duration {
+	minutes, err := strconv.Atoi(settings.GetSettings().IMAGE_URL_MINUTES)
+	if err != nil || minutes <= 0 {
+		minutes = DEFAULT_IMAGE_URL_MINUTES
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+func signImageURLs(keys []string) ([]string, error) {
+	if settings.GetSettings().IMAGE_URL_STRATEGY == IMAGE_URL_NATS {
+		return requestImageURLs(keys)
+	}
+	urls := make([]string, len(keys))
+	for i, key := range keys {
+		url, err := fileStorage.Presign(key, imageURLExpiry)
+		if err != nil {
+			return nil, err
+		}
+		urls[i] = url
+	}
+	return urls, nil
+}
+
+func requestImageURLs(keys []string) ([]string, error) {
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := nats.Request("get_aws_token_access", data)
+	if err != nil {
+		return nil, err
+	}
+	var urls []string
+	if err := json.Unmarshal(msg.Data, &urls); err != nil {
+		return nil, err
+	}
+	if len(urls) != len(keys) {
+		return nil, fmt.Errorf(
+			"se esperaban %d URLs de imágenes, se recibieron %d",
+			len(keys),
+			len(urls),
+		)
+	}
+	return urls, nil
+}
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"github.com/CPU-commits/Intranet_BNews/src/media"
+	"github.com/CPU-commits/Intranet_BNews/src/models"
+	"github.com/CPU-commits/Intranet_BNews/src/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Variants are stored next to the original, news/<id>.jpg -> news/<id>_card.jpg
+func getVariantKey(key string, variant *media.Encoded) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + "_" + variant.Name + "." + variant.Ext
+}
+
+func deleteKeys(keys []string) {
+	for _, key := range keys {
+		fileStorage.Delete(key)
+	}
+}
+
+// Rejected images have their own status, other errors use the given status
+func getImageErrorRes(err error, statusCode int) *ErrorRes {
+	switch err {
+	case media.ErrUnsupportedType:
+		statusCode = http.StatusUnsupportedMediaType
+	case media.ErrInvalidImage, media.ErrImageTooLarge:
+		statusCode = http.StatusUnprocessableEntity
+	case media.ErrFileTooLarge:
+		statusCode = http.StatusRequestEntityTooLarge
+	}
+	return &ErrorRes{
+		Err:        err,
+		StatusCode: statusCode,
+	}
+}
+
+func getContentHash(file io.ReadSeeker) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// File of a stored image, the same returned by the files service
+func getMediaFile(mediaData *models.Media) *models.FileDB {
+	return &models.FileDB{
+		ID: models.OID{
+			OID: mediaData.ID.Hex(),
+		},
+		Key:    mediaData.Key,
+		Status: true,
+	}
+}
+
+// Hold an image for a news that is going to use it, so it is not deleted
+// by a concurrent release. Images being deleted have no refs left and are
+// never held. The caller releases the hold once the news counts the image
+func holdMedia(filter bson.D) (*models.Media, error) {
+	var mediaData *models.Media
+	err := mediaModel.Use().FindOneAndUpdate(
+		db.Ctx,
+		append(filter, primitive.E{
+			Key: "refs",
+			Value: bson.M{
+				"$gt": 0,
+			},
+		}),
+		bson.D{
+			{
+				Key: "$inc",
+				Value: bson.M{
+					"refs": 1,
+				},
+			},
+		},
+	).Decode(&mediaData)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return mediaData, err
+}
+
+func holdMediaByHash(hash string) (*models.Media, error) {
+	return holdMedia(bson.D{
+		{
+			Key:   "hash",
+			Value: hash,
+		},
+	})
+}
+
+// Process the image, store the original with its variants and register it.
+// An image with the same content is reused instead of stored again. The
+// returned image is held, see holdMedia
+func storeImage(file io.ReadSeeker, uploader primitive.ObjectID) (*models.FileDB, error) {
+	hash, err := getContentHash(file)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := holdMediaByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if stored != nil {
+		return getMediaFile(stored), nil
+	}
+	// Each encoded image is stored as soon as it is ready. Keys are unique
+	// per upload, a concurrent upload of the same content never shares them
+	var key string
+	var keys []string
+	var variants []models.MediaVariant
+	processed, err := media.Process(file, func(encoded *media.Encoded) error {
+		encodedKey := storage.NewKey(encoded.Ext)
+		if encoded.Name == media.ORIGINAL_NAME {
+			key = encodedKey
+		} else {
+			encodedKey = getVariantKey(key, encoded)
+		}
+		err := fileStorage.Put(encodedKey, bytes.NewReader(encoded.Data), encoded.ContentType)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, encodedKey)
+		if encoded.Name != media.ORIGINAL_NAME {
+			variants = append(variants, models.MediaVariant{
+				Name:   encoded.Name,
+				Width:  encoded.Width,
+				Height: encoded.Height,
+				Key:    encodedKey,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		deleteKeys(keys)
+		return nil, err
+	}
+	fileDb, err := registerImage(key)
+	if err != nil {
+		deleteKeys(keys)
+		return nil, err
+	}
+	fileObjectId, err := primitive.ObjectIDFromHex(fileDb.ID.OID)
+	if err != nil {
+		return nil, err
+	}
+	mediaData := mediaModel.NewModel(
+		fileObjectId,
+		key,
+		hash,
+		uploader,
+		processed.Original.Width,
+		processed.Original.Height,
+		variants,
+		processed.BlurHash,
+		processed.Color,
+	)
+	// Held by the caller
+	mediaData.Refs = 1
+	_, err = mediaModel.Use().InsertOne(db.Ctx, mediaData)
+	// The same content was stored by a concurrent upload, its image is used
+	// and this one is deleted. The original is deleted with its file
+	if mongo.IsDuplicateKeyError(err) {
+		nats.Request("delete_image", []byte(fileDb.ID.OID))
+		deleteKeys(keys[1:])
+		stored, err = holdMediaByHash(hash)
+		if err != nil || stored == nil {
+			return nil, fmt.Errorf("no se pudo guardar la imagen")
+		}
+		return getMediaFile(stored), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fileDb, nil
+}
+
+// Count the news as user of the image, once per news. Revisions keep
+// previous images of a news, so they count as used by it.
+// Returns true if the image was counted
+func retainImage(imageObjectId primitive.ObjectID, newsData *models.News) (bool, error) {
+	if newsData != nil {
+		if newsData.Img == imageObjectId {
+			return false, nil
+		}
+		total, err := revisionsModel.Use().CountDocuments(db.Ctx, bson.D{
+			{
+				Key:   "news",
+				Value: newsData.ID,
+			},
+			{
+				Key:   "img",
+				Value: imageObjectId,
+			},
+		})
+		if err != nil {
+			return false, err
+		}
+		if total > 0 {
+			return false, nil
+		}
+	}
+	_, err := mediaModel.Use().UpdateOne(
+		db.Ctx,
+		bson.D{
+			{
+				Key:   "_id",
+				Value: imageObjectId,
+			},
+		},
+		bson.D{
+			{
+				Key: "$inc",
+				Value: bson.M{
+					"refs": 1,
+				},
+			},
+		},
+	)
+	return err == nil, err
+}
+
+// A news stopped using the image, it is deleted when no other news uses it
+func releaseImage(imageObjectId primitive.ObjectID) error {
+	var mediaData *models.Media
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := mediaModel.Use().FindOneAndUpdate(
+		db.Ctx,
+		bson.D{
+			{
+				Key:   "_id",
+				Value: imageObjectId,
+			},
+		},
+		bson.D{
+			{
+				Key: "$inc",
+				Value: bson.M{
+					"refs": -1,
+				},
+			},
+		},
+		opts,
+	).Decode(&mediaData)
+	// Images uploaded before processing belong to a single news
+	if err != nil && err != mongo.ErrNoDocuments {
+		return err
+	}
+	if mediaData != nil && mediaData.Refs > 0 {
+		return nil
+	}
+	if _, err := nats.Request("delete_image", []byte(imageObjectId.Hex())); err != nil {
+		return err
+	}
+	return deleteMedia(imageObjectId)
+}
+
+// Release the hold of storeImage or holdMedia, an image no news counted
+// is deleted
+func releaseHeldImage(fileDb *models.FileDB) {
+	imageObjectId, err := primitive.ObjectIDFromHex(fileDb.ID.OID)
+	if err != nil {
+		return
+	}
+	if err := releaseImage(imageObjectId); err != nil {
+		log.Printf("Error releasing image %s: %v\n", fileDb.ID.OID, err)
+	}
+}
+
+// The original is deleted with its file, variants are only known here
+func deleteMedia(imageObjectId primitive.ObjectID) error {
+	var mediaData *models.Media
+	err := mediaModel.Use().FindOneAndDelete(db.Ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: imageObjectId,
+		},
+	}).Decode(&mediaData)
+	// Images uploaded before processing have no variants
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, variant := range mediaData.Variants {
+		if err := fileStorage.Delete(variant.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"github.com/CPU-commits/Intranet_BNews/src/models"
+	"github.com/CPU-commits/Intranet_BNews/src/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var mediaService *MediaService
+
+type MediaService struct{}
+
+// Images the user can reuse. Teachers and student directives only reuse
+// their own uploads, directives reuse every image of the news
+func (m *MediaService) getFilterMedia(claims *Claims) (bson.M, *ErrorRes) {
+	switch claims.UserType {
+	case models.DIRECTOR, models.DIRECTIVE:
+		return bson.M{}, nil
+	case models.TEACHER, models.STUDENT_DIRECTIVE:
+		userObjectID, err := primitive.ObjectIDFromHex(claims.ID)
+		if err != nil {
+			return nil, &ErrorRes{
+				Err:        err,
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+		return bson.M{
+			"uploader": userObjectID,
+		}, nil
+	}
+	return nil, &ErrorRes{
+		Err:        fmt.Errorf("Unauthorized"),
+		StatusCode: http.StatusUnauthorized,
+	}
+}
+
+// The image of the library is held, see holdMedia
+func (m *MediaService) holdImage(idImage string, claims *Claims) (*models.Media, *ErrorRes) {
+	imageObjectId, err := primitive.ObjectIDFromHex(idImage)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	filter, errRes := m.getFilterMedia(claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	filterHold := bson.D{
+		{
+			Key:   "_id",
+			Value: imageObjectId,
+		},
+	}
+	for key, value := range filter {
+		filterHold = append(filterHold, primitive.E{
+			Key:   key,
+			Value: value,
+		})
+	}
+	mediaData, err := holdMedia(filterHold)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	if mediaData == nil {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("no existe la imagen"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	return mediaData, nil
+}
+
+// Sign the original and the thumbnail of each image, images processed
+// before the variants use the original as thumbnail
+func (m *MediaService) setMediaURLs(mediaData []MediaResponse) error {
+	var keys []string
+	for i := range mediaData {
+		thumbnailKey := mediaData[i].Key
+		for _, variant := range mediaData[i].Variants {
+			if variant.Name == "thumbnail" {
+				thumbnailKey = variant.Key
+			}
+		}
+		keys = append(keys, mediaData[i].Key, thumbnailKey)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	urls, err := getImageURLs(keys)
+	if err != nil {
+		return err
+	}
+	for i := range mediaData {
+		mediaData[i].URL = urls[i*2]
+		mediaData[i].Thumbnail = urls[i*2+1]
+	}
+	return nil
+}
+
+func (m *MediaService) GetMedia(
+	skip string,
+	limit string,
+	claims *Claims,
+) ([]MediaResponse, int, *ErrorRes) {
+	skipNumber, limitNumber, err := utils.ParseSkipLimit(skip, limit)
+	if err != nil {
+		return nil, 0, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	filter, errRes := m.getFilterMedia(claims)
+	if errRes != nil {
+		return nil, 0, errRes
+	}
+	cursor, err := mediaModel.Use().Aggregate(db.Ctx, mongo.Pipeline{
+		bson.D{
+			{
+				Key:   "$match",
+				Value: filter,
+			},
+		},
+		bson.D{
+			{
+				Key: "$sort",
+				Value: bson.D{
+					{Key: "date", Value: -1},
+					{Key: "_id", Value: -1},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key:   "$skip",
+				Value: skipNumber,
+			},
+		},
+		bson.D{
+			{
+				Key:   "$limit",
+				Value: limitNumber,
+			},
+		},
+		bson.D{
+			{
+				Key: "$lookup",
+				Value: bson.M{
+					"from":         "users",
+					"localField":   "uploader",
+					"foreignField": "_id",
+					"as":           "uploader",
+					"pipeline": bson.A{
+						bson.M{
+							"$project": bson.M{
+								"name":            1,
+								"first_lastname":  1,
+								"second_lastname": 1,
+							},
+						},
+					},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$project",
+				Value: bson.M{
+					"key":      1,
+					"refs":     1,
+					"width":    1,
+					"height":   1,
+					"variants": 1,
+					"blurhash": 1,
+					"color":    1,
+					"date":     1,
+					"uploader": bson.M{
+						"$arrayElemAt": bson.A{"$uploader", 0},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, 0, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	mediaData := []MediaResponse{}
+	if err := cursor.All(db.Ctx, &mediaData); err != nil {
+		return nil, 0, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	if err := m.setMediaURLs(mediaData); err != nil {
+		return nil, 0, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	total, err := mediaModel.Use().CountDocuments(db.Ctx, filter)
+	if err != nil {
+		return nil, 0, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return mediaData, int(total), nil
+}
+
+func NewMediaService() *MediaService {
+	if mediaService == nil {
+		mediaService = &MediaService{}
+	}
+	return mediaService
+}
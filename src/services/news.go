@@ -6,17 +6,19 @@ import (
 	"mime/multipart"
 	"net/http"
 	"strconv"
-	"sync"
+	"strings"
 	"time"
 
 	"github.com/CPU-commits/Intranet_BNews/src/db"
 	"github.com/CPU-commits/Intranet_BNews/src/forms"
 	"github.com/CPU-commits/Intranet_BNews/src/models"
 	"github.com/CPU-commits/Intranet_BNews/src/res"
+	"github.com/CPU-commits/Intranet_BNews/src/utils"
 	"github.com/gosimple/slug"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var newsService *NewsService
@@ -56,10 +58,36 @@ func (news *NewsService) getLookupFile() bson.D {
 				"foreignField": "_id",
 				"as":           "image",
 				"pipeline": bson.A{
+					bson.M{
+						"$lookup": bson.M{
+							"from":         models.MEDIA_COLLECTION,
+							"localField":   "_id",
+							"foreignField": "_id",
+							"as":           "media",
+						},
+					},
 					bson.M{
 						"$project": bson.M{
 							"url": 1,
 							"key": 1,
+							"width": bson.M{
+								"$arrayElemAt": bson.A{"$media.width", 0},
+							},
+							"height": bson.M{
+								"$arrayElemAt": bson.A{"$media.height", 0},
+							},
+							"blurhash": bson.M{
+								"$arrayElemAt": bson.A{"$media.blurhash", 0},
+							},
+							"color": bson.M{
+								"$arrayElemAt": bson.A{"$media.color", 0},
+							},
+							"variants": bson.M{
+								"$ifNull": bson.A{
+									bson.M{"$arrayElemAt": bson.A{"$media.variants", 0}},
+									bson.A{},
+								},
+							},
 						},
 					},
 				},
@@ -68,28 +96,378 @@ func (news *NewsService) getLookupFile() bson.D {
 	}
 }
 
-func (news *NewsService) getMatchStatusTrue(newsType string) bson.D {
+func (news *NewsService) getLookupCategory() bson.D {
 	return bson.D{
 		{
-			Key: "$match",
+			Key: "$lookup",
 			Value: bson.M{
-				"status": true,
-				"type":   newsType,
+				"from":         models.CATEGORIES_COLLECTION,
+				"localField":   "category",
+				"foreignField": "_id",
+				"as":           "category",
+				"pipeline": bson.A{
+					bson.M{
+						"$project": bson.M{
+							"name": 1,
+							"slug": 1,
+						},
+					},
+				},
 			},
 		},
 	}
 }
 
-func uploadImage(file *multipart.FileHeader) (*models.FileDB, error) {
-	// Upload file to S3
-	_, key, err := aws.UploadFile(file)
+func (news *NewsService) getFilterStatusTrue(newsType string, reader *Reader) bson.M {
+	return bson.M{
+		"status": true,
+		"type":   newsType,
+		"$and": bson.A{
+			reader.getFilterAudience(),
+		},
+		// Readers only see published news, authors also their own drafts
+		"$or": bson.A{
+			bson.M{
+				"state": models.NEWS_STATE_PUBLISHED,
+			},
+			bson.M{
+				"author_id": reader.ID,
+				"state": bson.M{
+					"$in": bson.A{
+						models.NEWS_STATE_DRAFT,
+						models.NEWS_STATE_SCHEDULED,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (n *NewsService) verifyIdentity(newsType string, authorID string, claims *Claims) *ErrorRes {
+	// Teachers only manage their own news
+	if claims.UserType == models.TEACHER {
+		if newsType != "global" || authorID != claims.ID {
+			return &ErrorRes{
+				Err:        fmt.Errorf("Unauthorized"),
+				StatusCode: http.StatusUnauthorized,
+			}
+		}
+		return nil
+	}
+	if newsType == "global" && (claims.UserType != models.DIRECTIVE && claims.UserType != models.DIRECTOR) {
+		return &ErrorRes{
+			Err:        fmt.Errorf("Unauthorized"),
+			StatusCode: http.StatusUnauthorized,
+		}
+	}
+	if newsType == "student" && claims.UserType != models.STUDENT_DIRECTIVE {
+		return &ErrorRes{
+			Err:        fmt.Errorf("Unauthorized"),
+			StatusCode: http.StatusUnauthorized,
+		}
+	}
+	return nil
+}
+
+// News the user can manage, news in the trash can only be restored
+func (n *NewsService) getNewsToManage(id string, claims *Claims) (*models.News, *ErrorRes) {
+	return n.findNewsToManage(id, true, claims)
+}
+
+func (n *NewsService) getTrashedNewsToManage(id string, claims *Claims) (*models.News, *ErrorRes) {
+	return n.findNewsToManage(id, false, claims)
+}
+
+func (n *NewsService) findNewsToManage(id string, status bool, claims *Claims) (*models.News, *ErrorRes) {
+	idObjectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("noticia no encontrada"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	var findNews *models.News
+	cursorNews := newsModel.Use().FindOne(db.Ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: idObjectId,
+		},
+		{
+			Key:   "status",
+			Value: status,
+		},
+	})
+	err = cursorNews.Decode(&findNews)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("noticia no encontrada"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	// Verify identity
+	if errRes := n.verifyIdentity(findNews.Type, findNews.AuthorId.Hex(), claims); errRes != nil {
+		return nil, errRes
+	}
+	return findNews, nil
+}
+
+func (n *NewsService) notifyNews(newsData *models.News) error {
+	// Get image key
+	images, err := n.getNews(mongo.Pipeline{
+		bson.D{
+			{
+				Key: "$match",
+				Value: bson.M{
+					"_id": newsData.ID,
+				},
+			},
+		},
+		n.getLookupFile(),
+		bson.D{
+			{
+				Key: "$project",
+				Value: bson.M{
+					"status": 1,
+					"image": bson.M{
+						"$arrayElemAt": bson.A{
+							"$image", 0,
+						},
+					},
+				},
+			},
+		},
+	}, false)
+	if err != nil {
+		return err
+	}
+	if images == nil {
+		return fmt.Errorf("noticia no encontrada")
+	}
+	return nats.PublishEncode("notify/global", &res.Notify{
+		Title:    newsData.Title,
+		Link:     fmt.Sprintf("/noticias/%s", newsData.Url),
+		Img:      images[0].Image.Key,
+		Type:     newsData.Type,
+		Audience: newsData.Audience,
+	})
+}
+
+func (news *NewsService) getProjectListStage() bson.D {
+	return bson.D{
+		{
+			Key: "$project",
+			Value: bson.M{
+				"title":       1,
+				"headline":    1,
+				"upload_date": 1,
+				"url":         1,
+				"type":        1,
+				"status":      1,
+				"state":       1,
+				"publish_at":  1,
+				"expires_at":  1,
+				"image": bson.M{
+					"$arrayElemAt": bson.A{
+						"$image", 0,
+					},
+				},
+				"author": bson.M{
+					"$arrayElemAt": bson.A{
+						"$author", 0,
+					},
+				},
+				"reactions": bson.M{
+					"$ifNull": bson.A{"$reactions", bson.M{}},
+				},
+				"comments": bson.M{
+					"$ifNull": bson.A{"$comments", 0},
+				},
+				"comments_disabled": 1,
+				"category": bson.M{
+					"$arrayElemAt": bson.A{
+						"$category", 0,
+					},
+				},
+				"tags": bson.M{
+					"$ifNull": bson.A{"$tags", bson.A{}},
+				},
+				"audience": 1,
+				"likes": bson.M{
+					"$ifNull": bson.A{"$reactions." + models.REACTION_LIKE, 0},
+				},
+				"reaction": bson.M{
+					"$arrayElemAt": bson.A{"$own_reaction.reaction", 0},
+				},
+				"read": bson.M{
+					"$gt": bson.A{
+						bson.M{"$size": "$own_read"},
+						0,
+					},
+				},
+				"requires_ack": 1,
+				"version":      1,
+				"acknowledged": bson.M{
+					"$gt": bson.A{
+						bson.M{"$size": "$own_acknowledgement"},
+						0,
+					},
+				},
+				"like": bson.M{
+					"$eq": bson.A{
+						bson.M{
+							"$arrayElemAt": bson.A{"$own_reaction.reaction", 0},
+						},
+						models.REACTION_LIKE,
+					},
+				},
+				// Last change of the news, their counters or the state of the user
+				"modified_date": bson.M{
+					"$max": bson.A{
+						"$upload_date",
+						"$update_date",
+						"$activity_date",
+						bson.M{"$max": "$own_reaction.date"},
+						bson.M{"$max": "$own_read.date"},
+						bson.M{"$max": "$own_acknowledgement.date"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (news *NewsService) getLookupReaction(userObjectID primitive.ObjectID) bson.D {
+	return bson.D{
+		{
+			Key: "$lookup",
+			Value: bson.M{
+				"from":         models.REACTIONS_COLLECTION,
+				"localField":   "_id",
+				"foreignField": "news",
+				"as":           "own_reaction",
+				"pipeline": bson.A{
+					bson.M{
+						"$match": bson.M{
+							"user": userObjectID,
+						},
+					},
+					bson.M{
+						"$project": bson.M{
+							"reaction": 1,
+							"date":     1,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (news *NewsService) getLookupRead(userObjectID primitive.ObjectID) bson.D {
+	return bson.D{
+		{
+			Key: "$lookup",
+			Value: bson.M{
+				"from":         models.READS_COLLECTION,
+				"localField":   "_id",
+				"foreignField": "news",
+				"as":           "own_read",
+				"pipeline": bson.A{
+					bson.M{
+						"$match": bson.M{
+							"user": userObjectID,
+						},
+					},
+					bson.M{
+						"$project": bson.M{
+							"date": 1,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (news *NewsService) getLookupAcknowledgement(userObjectID primitive.ObjectID) bson.D {
+	return bson.D{
+		{
+			Key: "$lookup",
+			Value: bson.M{
+				"from":         models.ACKNOWLEDGEMENTS_COLLECTION,
+				"localField":   "_id",
+				"foreignField": "news",
+				"as":           "own_acknowledgement",
+				"pipeline": bson.A{
+					bson.M{
+						"$match": bson.M{
+							"user": userObjectID,
+						},
+					},
+					bson.M{
+						"$project": bson.M{
+							"date": 1,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func uploadImage(file *multipart.FileHeader, claims *Claims) (*models.FileDB, error) {
+	uploader, err := primitive.ObjectIDFromHex(claims.ID)
 	if err != nil {
 		return nil, err
 	}
+	openFile, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer openFile.Close()
+	// Upload file to the storage
+	return storeImage(openFile, uploader)
+}
+
+// Image of the news, uploaded or taken from the media library. The image
+// is held until the news counts it, the caller releases it
+func getNewsImage(
+	file *multipart.FileHeader,
+	idImage string,
+	claims *Claims,
+) (*models.FileDB, *ErrorRes) {
+	if file != nil && idImage != "" {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("envía una imagen o selecciona una de la biblioteca, no ambas"),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if idImage != "" {
+		mediaData, errRes := mediaService.holdImage(idImage, claims)
+		if errRes != nil {
+			return nil, errRes
+		}
+		return getMediaFile(mediaData), nil
+	}
+	if file == nil {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("la imagen de la noticia es requerida"),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	fileDb, err := uploadImage(file, claims)
+	if err != nil {
+		return nil, getImageErrorRes(err, http.StatusBadRequest)
+	}
+	return fileDb, nil
+}
+
+// Register the uploaded file, it is removed if it can not be registered
+func registerImage(key string) (*models.FileDB, error) {
 	// Request NATS (Get id file insert)
 	msg, err := nats.Request("upload_image", []byte(key))
 	if err != nil {
-		aws.DeleteFile(key)
+		fileStorage.Delete(key)
 		return nil, err
 	}
 	// Process response NATS
@@ -116,58 +494,53 @@ func (news *NewsService) getNews(pipeline mongo.Pipeline, requestImage bool) ([]
 	if !newsData[0].Status {
 		return newsData, nil
 	}
-	// Request nats
+	// Signed URLs
 	if requestImage {
 		var images []string
 		for i := 0; i < len(newsData); i++ {
 			images = append(images, newsData[i].Image.Key)
+			for _, variant := range newsData[i].Image.Variants {
+				images = append(images, variant.Key)
+			}
 		}
-		data, err := json.Marshal(images)
-		if err != nil {
-			return nil, err
-		}
-		msg, err := nats.Request("get_aws_token_access", data)
+		imagesURLs, err := getImageURLs(images)
 		if err != nil {
 			return nil, err
 		}
-		var imagesURLs []string
-		json.Unmarshal(msg.Data, &imagesURLs)
-		// Add image URLs to Response
+		// Add image URLs to Response, in the same order of the keys
+		j := 0
 		for i := 0; i < len(newsData); i++ {
-			newsData[i].Image.URL = imagesURLs[i]
+			image := &newsData[i].Image
+			image.URL = imagesURLs[j]
+			j++
+			var srcSet []string
+			for k := range image.Variants {
+				image.Variants[k].URL = imagesURLs[j]
+				srcSet = append(srcSet, fmt.Sprintf("%s %dw", imagesURLs[j], image.Variants[k].Width))
+				j++
+			}
+			// The original is the widest candidate
+			if image.Width != 0 {
+				srcSet = append(srcSet, fmt.Sprintf("%s %dw", image.URL, image.Width))
+			}
+			image.SrcSet = strings.Join(srcSet, ", ")
 		}
 	}
 	return newsData, nil
 }
 
-func (n *NewsService) GetSingleNews(slug string, claims *Claims) (*NewsResponse, *ErrorRes) {
+// The news is marked as read even if the user has it cached
+func (n *NewsService) GetSingleNews(slug string, claims *Claims) (*NewsResponse, *Validator, *ErrorRes) {
+	reader, errRes := getReader(claims)
+	if errRes != nil {
+		return nil, nil, errRes
+	}
 	lookUpStage := n.getLookupFile()
 	lookUpUserStage := n.getLookupUser()
-	projectStage := bson.D{
-		{
-			Key: "$project",
-			Value: bson.M{
-				"title":       1,
-				"headline":    1,
-				"upload_date": 1,
-				"url":         1,
-				"type":        1,
-				"update_date": 1,
-				"status":      1,
-				"body":        1,
-				"image": bson.M{
-					"$arrayElemAt": bson.A{
-						"$image", 0,
-					},
-				},
-				"author": bson.M{
-					"$arrayElemAt": bson.A{
-						"$author", 0,
-					},
-				},
-			},
-		},
-	}
+	projectStage := n.getProjectListStage()
+	project := projectStage[0].Value.(bson.M)
+	project["update_date"] = 1
+	project["body"] = 1
 	matchStage := bson.D{
 		{
 			Key: "$match",
@@ -176,6 +549,12 @@ func (n *NewsService) GetSingleNews(slug string, claims *Claims) (*NewsResponse,
 					Key:   "url",
 					Value: slug,
 				},
+				{
+					Key: "$and",
+					Value: bson.A{
+						reader.getFilterAudience(),
+					},
+				},
 			},
 		},
 	}
@@ -183,196 +562,378 @@ func (n *NewsService) GetSingleNews(slug string, claims *Claims) (*NewsResponse,
 		matchStage,
 		lookUpStage,
 		lookUpUserStage,
+		n.getLookupCategory(),
+		n.getLookupReaction(reader.ID),
+		n.getLookupRead(reader.ID),
+		n.getLookupAcknowledgement(reader.ID),
 		projectStage,
 	}, true)
 	if err != nil {
-		return nil, &ErrorRes{
+		return nil, nil, &ErrorRes{
 			Err:        err,
 			StatusCode: http.StatusBadRequest,
 		}
 	}
 	if newsData == nil {
-		return nil, &ErrorRes{
+		return nil, nil, &ErrorRes{
 			Err:        fmt.Errorf("no pudimos encontrar la noticia"),
 			StatusCode: http.StatusNotFound,
 		}
 	}
 	if !newsData[0].Status {
-		return nil, &ErrorRes{
+		return nil, nil, &ErrorRes{
 			Err:        fmt.Errorf("esta noticia ya no está disponible"),
 			StatusCode: http.StatusGone,
 		}
 	}
-	// Validate
-	newsType := newsData[0].Type
-	if newsType == "student" {
-		if claims.UserType != models.STUDENT && claims.UserType != models.STUDENT_DIRECTIVE {
-			return nil, &ErrorRes{
-				Err:        fmt.Errorf("no tienes acceso a esta noticia"),
-				StatusCode: http.StatusUnauthorized,
-			}
+	// Validate, drafts are only visible to their author
+	state := newsData[0].State
+	if (state == models.NEWS_STATE_DRAFT || state == models.NEWS_STATE_SCHEDULED) && newsData[0].Author.ID != claims.ID {
+		return nil, nil, &ErrorRes{
+			Err:        fmt.Errorf("no pudimos encontrar la noticia"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	if !reader.canSeeType(newsData[0].Type) {
+		return nil, nil, &ErrorRes{
+			Err:        fmt.Errorf("no tienes acceso a esta noticia"),
+			StatusCode: http.StatusUnauthorized,
 		}
 	}
-	return &newsData[0], nil
+	// Read
+	if newsData[0].State == models.NEWS_STATE_PUBLISHED && !newsData[0].Read {
+		newsObjectId, err := primitive.ObjectIDFromHex(newsData[0].ID)
+		if err == nil {
+			readsService.markRead(newsObjectId, reader.ID)
+		}
+	}
+	validator, err := newSingleNewsValidator(&newsData[0])
+	if err != nil {
+		return nil, nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return &newsData[0], validator, nil
 }
 
-func (n *NewsService) GetNews(
+// Opaque position of a news in the listing, sorted by upload_date and _id
+func encodeNewsCursor(news NewsResponse) string {
+	return utils.EncodeCursor(news.UploadDate, news.ID)
+}
+
+// Stages to get a page of news
+type newsListQuery struct {
+	filter bson.M
+	stages mongo.Pipeline
+	limit  int
+	reader *Reader
+}
+
+func (n *NewsService) getNewsListQuery(
 	skip string,
-	total bool,
 	limit string,
+	after string,
 	newsType string,
+	category string,
+	tag string,
 	claims *Claims,
-) ([]NewsResponse, int, *ErrorRes) {
-	// Recovery if close channel
-	defer func() {
-		recovery := recover()
-		if recovery != nil {
-			fmt.Printf("A channel closed")
-		}
-	}()
-
-	skipNumber, err := strconv.Atoi(skip)
+) (*newsListQuery, *ErrorRes) {
+	skipNumber, limitNumber, err := utils.ParseSkipLimit(skip, limit)
 	if err != nil {
-		return nil, 0, &ErrorRes{
+		return nil, &ErrorRes{
 			Err:        err,
 			StatusCode: http.StatusBadRequest,
 		}
 	}
-	limitNumber, err := strconv.Atoi(limit)
-	if err != nil {
-		return nil, 0, &ErrorRes{
-			Err:        err,
-			StatusCode: http.StatusBadRequest,
+	reader, errRes := getReader(claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	if !reader.canSeeType(newsType) {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("no tienes acceso a estas noticias"),
+			StatusCode: http.StatusUnauthorized,
+		}
+	}
+	filter := n.getFilterStatusTrue(newsType, reader)
+	if category != "" {
+		categoryObjectId, err := primitive.ObjectIDFromHex(category)
+		if err != nil {
+			return nil, &ErrorRes{
+				Err:        fmt.Errorf("categoría inválida"),
+				StatusCode: http.StatusBadRequest,
+			}
 		}
+		filter["category"] = categoryObjectId
+	}
+	if tag != "" {
+		filter["tags"] = strings.ToLower(strings.TrimSpace(tag))
+	}
+	matchStage := bson.D{
+		{
+			Key:   "$match",
+			Value: filter,
+		},
+	}
+	// Cursor mode, news after the cursor instead of skipping
+	if after != "" {
+		uploadDate, idObjectId, err := utils.DecodeCursor(after)
+		if err != nil {
+			return nil, &ErrorRes{
+				Err:        err,
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+		matchStage = bson.D{
+			{
+				Key: "$match",
+				Value: bson.M{
+					"$and": bson.A{
+						filter,
+						bson.M{
+							"$or": bson.A{
+								bson.M{
+									"upload_date": bson.M{
+										"$lt": uploadDate,
+									},
+								},
+								bson.M{
+									"upload_date": uploadDate,
+									"_id": bson.M{
+										"$lt": idObjectId,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		skipNumber = 0
 	}
 	sortStage := bson.D{
 		{
 			Key: "$sort",
 			Value: bson.D{
 				{Key: "upload_date", Value: -1},
+				{Key: "_id", Value: -1},
 			},
 		},
 	}
-	limitStage := bson.D{
-		{
-			Key:   "$limit",
-			Value: limitNumber,
-		},
-	}
 	skipStage := bson.D{
 		{
 			Key:   "$skip",
 			Value: skipNumber,
 		},
 	}
-	lookUpStage := n.getLookupFile()
-	lookUpUserStage := n.getLookupUser()
-	projectStage := bson.D{
+	// One more to know if there is a next page
+	limitStage := bson.D{
 		{
-			Key: "$project",
-			Value: bson.M{
-				"title":       1,
-				"headline":    1,
-				"upload_date": 1,
-				"url":         1,
-				"type":        1,
-				"status":      1,
-				"image": bson.M{
-					"$arrayElemAt": bson.A{
-						"$image", 0,
-					},
-				},
-				"author": bson.M{
-					"$arrayElemAt": bson.A{
-						"$author", 0,
-					},
-				},
-			},
+			Key:   "$limit",
+			Value: limitNumber + 1,
 		},
 	}
-	newsData, err := n.getNews(mongo.Pipeline{
-		n.getMatchStatusTrue(newsType),
-		sortStage,
-		limitStage,
-		skipStage,
-		lookUpStage,
-		lookUpUserStage,
-		projectStage,
-	}, true)
+	return &newsListQuery{
+		filter: filter,
+		stages: mongo.Pipeline{
+			matchStage,
+			sortStage,
+			skipStage,
+			limitStage,
+		},
+		limit:  limitNumber,
+		reader: reader,
+	}, nil
+}
+
+func (n *NewsService) GetNews(
+	skip string,
+	total bool,
+	limit string,
+	after string,
+	newsType string,
+	category string,
+	tag string,
+	claims *Claims,
+) (*NewsPage, *ErrorRes) {
+	userObjectID, err := primitive.ObjectIDFromHex(claims.ID)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	// Cache, looked up before resolving the audience of the reader
+	cacheKey := getNewsListKey(
+		userObjectID,
+		skip,
+		strconv.FormatBool(total),
+		limit,
+		after,
+		newsType,
+		category,
+		tag,
+	)
+	if page, ok := getCachedNewsList(cacheKey); ok {
+		return page, nil
+	}
+	query, errRes := n.getNewsListQuery(skip, limit, after, newsType, category, tag, claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	reader := query.reader
+	filter := query.filter
+	limitNumber := query.limit
+	pipeline := append(
+		query.stages,
+		n.getLookupFile(),
+		n.getLookupUser(),
+		n.getLookupCategory(),
+		n.getLookupReaction(reader.ID),
+		n.getLookupRead(reader.ID),
+		n.getLookupAcknowledgement(reader.ID),
+		n.getProjectListStage(),
+	)
+	newsData, err := n.getNews(pipeline, true)
 	if err != nil {
-		return nil, 0, &ErrorRes{
+		return nil, &ErrorRes{
 			Err:        err,
 			StatusCode: http.StatusServiceUnavailable,
 		}
 	}
-	if newsData == nil {
-		return newsData, 0, nil
+	page := &NewsPage{
+		News: newsData,
 	}
-	// Get likes
-	userObjectID, err := primitive.ObjectIDFromHex(claims.ID)
+	if len(newsData) > limitNumber {
+		page.News = newsData[:limitNumber]
+		page.NextCursor = encodeNewsCursor(page.News[len(page.News)-1])
+	}
+	if total {
+		totalData, err := newsModel.Use().CountDocuments(db.Ctx, filter)
+		if err != nil {
+			return nil, &ErrorRes{
+				Err:        err,
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+		page.Total = int(totalData)
+	}
+	// The validator is cached with the page, so it always matches the body
+	page.Validator, err = newNewsValidator(page.News, page.Total, page.NextCursor)
 	if err != nil {
-		return nil, 0, &ErrorRes{
+		return nil, &ErrorRes{
 			Err:        err,
-			StatusCode: http.StatusBadRequest,
+			StatusCode: http.StatusServiceUnavailable,
 		}
 	}
+	setCachedNewsList(cacheKey, page)
+	return page, nil
+}
 
-	var wg sync.WaitGroup
-	c := make(chan (int), 10)
-	for i := 0; i < len(newsData); i++ {
-		wg.Add(1)
-		c <- 1
-
-		go func(i int, wg *sync.WaitGroup, errRet *error) {
-			defer wg.Done()
-			// Get like user
-			var likeData *models.Likes
-
-			newsObjectId, _ := primitive.ObjectIDFromHex(newsData[i].ID)
-			cursor := likesModel.Use().FindOne(db.Ctx, bson.D{
-				{
-					Key:   "user",
-					Value: userObjectID,
-				},
-				{
-					Key:   "news",
-					Value: newsObjectId,
-				},
-			})
-			cursor.Decode(&likeData)
-			newsData[i].Like = (likeData != nil)
-			// Get likes news
-			count, err := likesModel.Use().CountDocuments(db.Ctx, bson.D{
-				{
-					Key:   "news",
-					Value: newsObjectId,
-				},
-			})
-			if err != nil {
-				*errRet = err
-			}
-			newsData[i].Likes = int(count)
-			<-c
-		}(i, &wg, &err)
+func (n *NewsService) GetArchivedNews(
+	skip string,
+	limit string,
+	month string,
+	year string,
+	newsType string,
+	claims *Claims,
+) ([]NewsResponse, *ErrorRes) {
+	skipNumber, limitNumber, err := utils.ParseSkipLimit(skip, limit)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+		}
 	}
-	wg.Wait()
+	yearNumber, err := strconv.Atoi(year)
 	if err != nil {
-		return nil, 0, &ErrorRes{
+		return nil, &ErrorRes{
 			Err:        err,
 			StatusCode: http.StatusBadRequest,
 		}
 	}
-	var totalData int64
-	if total {
-		totalData, err = newsModel.Use().CountDocuments(db.Ctx, bson.D{})
-		if err != nil {
-			return nil, 0, &ErrorRes{
-				Err:        err,
+	reader, errRes := getReader(claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	// Date range, the whole year if there is no month
+	from := time.Date(yearNumber, time.January, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(1, 0, 0)
+	if month != "" {
+		monthNumber, err := strconv.Atoi(month)
+		if err != nil || monthNumber < 1 || monthNumber > 12 {
+			return nil, &ErrorRes{
+				Err:        fmt.Errorf("el mes debe estar entre 1 y 12"),
 				StatusCode: http.StatusBadRequest,
 			}
 		}
+		from = time.Date(yearNumber, time.Month(monthNumber), 1, 0, 0, 0, 0, time.Local)
+		to = from.AddDate(0, 1, 0)
+	}
+	if !reader.canSeeType(newsType) {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("no tienes acceso a estas noticias"),
+			StatusCode: http.StatusUnauthorized,
+		}
+	}
+	matchStage := bson.D{
+		{
+			Key: "$match",
+			Value: bson.M{
+				"status": true,
+				"type":   newsType,
+				"state":  models.NEWS_STATE_ARCHIVED,
+				"upload_date": bson.M{
+					"$gte": primitive.NewDateTimeFromTime(from),
+					"$lt":  primitive.NewDateTimeFromTime(to),
+				},
+				"$and": bson.A{
+					reader.getFilterAudience(),
+				},
+			},
+		},
+	}
+	sortStage := bson.D{
+		{
+			Key: "$sort",
+			Value: bson.D{
+				{Key: "upload_date", Value: -1},
+			},
+		},
+	}
+	skipStage := bson.D{
+		{
+			Key:   "$skip",
+			Value: skipNumber,
+		},
+	}
+	limitStage := bson.D{
+		{
+			Key:   "$limit",
+			Value: limitNumber,
+		},
+	}
+	newsData, err := n.getNews(mongo.Pipeline{
+		matchStage,
+		sortStage,
+		skipStage,
+		limitStage,
+		n.getLookupFile(),
+		n.getLookupUser(),
+		n.getLookupCategory(),
+		n.getLookupReaction(reader.ID),
+		n.getLookupRead(reader.ID),
+		n.getLookupAcknowledgement(reader.ID),
+		n.getProjectListStage(),
+	}, true)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
 	}
-	return newsData, int(totalData), nil
+	return newsData, nil
 }
 
 func (n *NewsService) NewNews(
@@ -396,14 +957,39 @@ func (n *NewsService) NewNews(
 			StatusCode: http.StatusConflict,
 		}
 	}
-	// Upload image
-	fileDb, err := uploadImage(file)
+	if !news.ExpiresAt.IsZero() && !news.ExpiresAt.After(time.Now()) {
+		return primitive.NilObjectID, &ErrorRes{
+			Err:        fmt.Errorf("la fecha de expiración debe ser futura"),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if news.Category != "" {
+		if _, errRes := categoriesService.getCategory(news.Category); errRes != nil {
+			return primitive.NilObjectID, errRes
+		}
+	}
+	audience, err := models.NewAudience(news.Roles, news.Courses, news.Levels)
 	if err != nil {
 		return primitive.NilObjectID, &ErrorRes{
 			Err:        err,
 			StatusCode: http.StatusBadRequest,
 		}
 	}
+	if claims.UserType == models.TEACHER {
+		reader, errRes := getReader(claims)
+		if errRes != nil {
+			return primitive.NilObjectID, errRes
+		}
+		if errRes := reader.verifyAudience(audience); errRes != nil {
+			return primitive.NilObjectID, errRes
+		}
+	}
+	// Upload image
+	fileDb, errRes := getNewsImage(file, news.Image, claims)
+	if errRes != nil {
+		return primitive.NilObjectID, errRes
+	}
+	defer releaseHeldImage(fileDb)
 	// Upload news
 	var newsType string
 	if claims.UserType == models.STUDENT_DIRECTIVE {
@@ -418,62 +1004,58 @@ func (n *NewsService) NewNews(
 			StatusCode: http.StatusBadRequest,
 		}
 	}
-	uploadedNews, err := newsModel.Use().InsertOne(db.Ctx, newsData)
+	insertedID, err := n.insertNews(newsData)
 	if err != nil {
 		return primitive.NilObjectID, &ErrorRes{
 			Err:        err,
 			StatusCode: http.StatusServiceUnavailable,
 		}
 	}
-	// Notify news
-	nats.PublishEncode("notify/global", &res.Notify{
-		Title: news.Title,
-		Link:  fmt.Sprintf("/noticias/%s", newsData.Url),
-		Img:   fileDb.Key,
-		Type:  newsType,
-	})
-	return uploadedNews.InsertedID.(primitive.ObjectID), nil
+	invalidateNewsList()
+	// Notify news, drafts and scheduled news are notified at publish time
+	if newsData.State == models.NEWS_STATE_PUBLISHED {
+		nats.PublishEncode("notify/global", &res.Notify{
+			Title:    news.Title,
+			Link:     fmt.Sprintf("/noticias/%s", newsData.Url),
+			Img:      fileDb.Key,
+			Type:     newsType,
+			Audience: newsData.Audience,
+		})
+	}
+	return insertedID, nil
+}
+
+// The image is counted before the news is inserted, and released if the
+// news can not be inserted. A stored news always counts its image
+func (n *NewsService) insertNews(newsData *models.News) (primitive.ObjectID, error) {
+	if _, err := retainImage(newsData.Img, nil); err != nil {
+		return primitive.NilObjectID, err
+	}
+	result, err := newsModel.Use().InsertOne(db.Ctx, newsData)
+	if err != nil {
+		releaseImage(newsData.Img)
+		return primitive.NilObjectID, err
+	}
+	return result.InsertedID.(primitive.ObjectID), nil
 }
 
 func (n *NewsService) UpdateNews(
 	data forms.UpdateNewsDTO,
 	id string,
+	ifMatch string,
 	claims *Claims,
 ) (*models.News, *ErrorRes) {
-	idObjectId, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return nil, &ErrorRes{
-			Err:        fmt.Errorf("noticia no encontrada"),
-			StatusCode: http.StatusNotFound,
-		}
+	version, errRes := getIfMatchVersion(ifMatch)
+	if errRes != nil {
+		return nil, errRes
 	}
 	// Get news
-	var findNews *models.News
-	cursorNews := newsModel.Use().FindOne(db.Ctx, bson.D{
-		{
-			Key:   "_id",
-			Value: idObjectId,
-		},
-	})
-	err = cursorNews.Decode(&findNews)
-	if err != nil {
-		return nil, &ErrorRes{
-			Err:        fmt.Errorf("noticia no encontrada"),
-			StatusCode: http.StatusNotFound,
-		}
-	}
-	// Verify identity
-	if findNews.Type == "global" && (claims.UserType != models.DIRECTIVE && claims.UserType != models.DIRECTOR) {
-		return nil, &ErrorRes{
-			Err:        fmt.Errorf("Unauthorized"),
-			StatusCode: http.StatusUnauthorized,
-		}
+	findNews, errRes := n.getNewsToManage(id, claims)
+	if errRes != nil {
+		return nil, errRes
 	}
-	if findNews.Type == "student" && claims.UserType != models.STUDENT_DIRECTIVE {
-		return nil, &ErrorRes{
-			Err:        fmt.Errorf("Unauthorized"),
-			StatusCode: http.StatusUnauthorized,
-		}
+	if version != 0 && findNews.Version != version {
+		return n.getConflict(findNews.ID)
 	}
 	// Update data
 	update := bson.D{
@@ -482,14 +1064,12 @@ func (n *NewsService) UpdateNews(
 			Value: primitive.NewDateTimeFromTime(time.Now()),
 		},
 	}
-	if data.Img != nil {
-		fileDb, err := uploadImage(data.Img)
-		if err != nil {
-			return nil, &ErrorRes{
-				Err:        err,
-				StatusCode: http.StatusNotFound,
-			}
+	if data.Img != nil || data.Image != "" {
+		fileDb, errRes := getNewsImage(data.Img, data.Image, claims)
+		if errRes != nil {
+			return nil, errRes
 		}
+		defer releaseHeldImage(fileDb)
 		imgObjectId, err := primitive.ObjectIDFromHex(fileDb.ID.OID)
 		if err != nil {
 			return nil, &ErrorRes{
@@ -520,151 +1100,359 @@ func (n *NewsService) UpdateNews(
 			Value: data.Title,
 		})
 	}
+	if !data.ExpiresAt.IsZero() {
+		if !data.ExpiresAt.After(time.Now()) {
+			return nil, &ErrorRes{
+				Err:        fmt.Errorf("la fecha de expiración debe ser futura"),
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+		update = append(update, primitive.E{
+			Key:   "expires_at",
+			Value: primitive.NewDateTimeFromTime(data.ExpiresAt),
+		})
+	}
+	if data.Category != "" {
+		category, errRes := categoriesService.getCategory(data.Category)
+		if errRes != nil {
+			return nil, errRes
+		}
+		update = append(update, primitive.E{
+			Key:   "category",
+			Value: category.ID,
+		})
+	}
+	if data.Tags != nil {
+		update = append(update, primitive.E{
+			Key:   "tags",
+			Value: models.NormalizeTags(data.Tags),
+		})
+	}
+	if data.RequiresAck != nil {
+		update = append(update, primitive.E{
+			Key:   "requires_ack",
+			Value: *data.RequiresAck,
+		})
+	}
+	if data.Roles != nil || data.Courses != nil || data.Levels != nil {
+		audience, err := models.NewAudience(data.Roles, data.Courses, data.Levels)
+		if err != nil {
+			return nil, &ErrorRes{
+				Err:        err,
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+		if claims.UserType == models.TEACHER {
+			reader, errRes := getReader(claims)
+			if errRes != nil {
+				return nil, errRes
+			}
+			if errRes := reader.verifyAudience(audience); errRes != nil {
+				return nil, errRes
+			}
+		}
+		update = append(update, primitive.E{
+			Key:   "audience",
+			Value: audience,
+		})
+	}
 	// Update news
+	return n.updateNews(findNews, update, claims, primitive.NilObjectID, version)
+}
+
+// Set the update and keep the revision of the edit. With a version the
+// update only applies if the news was not modified since then
+func (n *NewsService) updateNews(
+	before *models.News,
+	update bson.D,
+	claims *Claims,
+	restoredFrom primitive.ObjectID,
+	version int,
+) (*models.News, *ErrorRes) {
+	// News moved to the trash meanwhile are not updated
+	filter := bson.D{
+		{
+			Key:   "_id",
+			Value: before.ID,
+		},
+		{
+			Key:   "status",
+			Value: true,
+		},
+	}
+	if version != 0 {
+		filter = append(filter, primitive.E{
+			Key:   "version",
+			Value: version,
+		})
+	}
+	// A new image is counted before the update, and released if it fails
+	var retainedImg primitive.ObjectID
+	for _, field := range update {
+		if field.Key != "img" {
+			continue
+		}
+		img := field.Value.(primitive.ObjectID)
+		retained, err := retainImage(img, before)
+		if err != nil {
+			return nil, &ErrorRes{
+				Err:        err,
+				StatusCode: http.StatusServiceUnavailable,
+			}
+		}
+		if retained {
+			retainedImg = img
+		}
+	}
 	var newsData *models.News
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 	cursor := newsModel.Use().FindOneAndUpdate(
-		db.Ctx, bson.D{
-			{
-				Key:   "_id",
-				Value: idObjectId,
-			},
-		},
+		db.Ctx,
+		filter,
 		bson.D{
 			{
 				Key:   "$set",
 				Value: update,
 			},
+			{
+				Key: "$inc",
+				Value: bson.M{
+					"version": 1,
+				},
+			},
 		},
+		opts,
 	)
-	err = cursor.Decode(&newsData)
+	err := cursor.Decode(&newsData)
+	if err != nil && !retainedImg.IsZero() {
+		releaseImage(retainedImg)
+	}
+	if err == mongo.ErrNoDocuments && version != 0 {
+		return n.getConflict(before.ID)
+	}
 	if err != nil {
 		return nil, &ErrorRes{
 			Err:        err,
 			StatusCode: http.StatusNotFound,
 		}
 	}
-	return newsData, nil
-}
-
-func (n *NewsService) DeleteNews(
-	id string,
-	claims *Claims,
-) *ErrorRes {
-	idObjectId, err := primitive.ObjectIDFromHex(id)
+	invalidateNewsList()
+	editorObjectId, err := primitive.ObjectIDFromHex(claims.ID)
 	if err != nil {
-		return &ErrorRes{
+		return nil, &ErrorRes{
 			Err:        err,
-			StatusCode: http.StatusNotFound,
+			StatusCode: http.StatusBadRequest,
 		}
 	}
-	// Get news
-	lookUpStage := n.getLookupFile()
-	lookUpUserStage := n.getLookupUser()
-	projectStage := bson.D{
-		{
-			Key: "$project",
-			Value: bson.M{
-				"title":       1,
-				"headline":    1,
-				"upload_date": 1,
-				"url":         1,
-				"type":        1,
-				"update_date": 1,
-				"body":        1,
-				"image": bson.M{
-					"$arrayElemAt": bson.A{
-						"$image", 0,
-					},
-				},
-				"author": bson.M{
-					"$arrayElemAt": bson.A{
-						"$author", 0,
+	err = revisionsService.saveRevision(before, update, editorObjectId, restoredFrom)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return newsData, nil
+}
+
+func (n *NewsService) publishNews(idObjectId primitive.ObjectID) error {
+	now := primitive.NewDateTimeFromTime(time.Now())
+	// Only one caller can flip the state, so the news is notified once
+	var newsData *models.News
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	cursor := newsModel.Use().FindOneAndUpdate(
+		db.Ctx,
+		bson.D{
+			{
+				Key:   "_id",
+				Value: idObjectId,
+			},
+			{
+				Key:   "status",
+				Value: true,
+			},
+			{
+				Key: "state",
+				Value: bson.M{
+					"$in": bson.A{
+						models.NEWS_STATE_DRAFT,
+						models.NEWS_STATE_SCHEDULED,
 					},
 				},
 			},
 		},
-	}
-	matchStage := bson.D{
-		{
-			Key: "$match",
-			Value: bson.D{
-				{
-					Key:   "_id",
-					Value: idObjectId,
+		bson.D{
+			{
+				Key: "$set",
+				Value: bson.M{
+					"state":       models.NEWS_STATE_PUBLISHED,
+					"upload_date": now,
+					"update_date": now,
 				},
-				{
-					Key:   "status",
-					Value: true,
+			},
+			{
+				Key: "$inc",
+				Value: bson.M{
+					"version": 1,
+				},
+			},
+			{
+				Key: "$unset",
+				Value: bson.M{
+					"publish_at": "",
 				},
 			},
 		},
+		opts,
+	)
+	if err := cursor.Decode(&newsData); err != nil {
+		return err
 	}
-	newsData, err := n.getNews(mongo.Pipeline{
-		matchStage,
-		lookUpStage,
-		lookUpUserStage,
-		projectStage,
-	}, false)
-	if err != nil {
-		return &ErrorRes{
-			Err:        err,
-			StatusCode: http.StatusNotFound,
-		}
+	invalidateNewsList()
+	return n.notifyNews(newsData)
+}
+
+func (n *NewsService) PublishNews(id string, claims *Claims) *ErrorRes {
+	findNews, errRes := n.getNewsToManage(id, claims)
+	if errRes != nil {
+		return errRes
 	}
-	if newsData == nil {
+	if findNews.State != models.NEWS_STATE_DRAFT && findNews.State != models.NEWS_STATE_SCHEDULED {
 		return &ErrorRes{
-			Err:        fmt.Errorf("no existe la noticia"),
-			StatusCode: http.StatusNotFound,
+			Err:        fmt.Errorf("la noticia ya fue publicada"),
+			StatusCode: http.StatusConflict,
 		}
 	}
-	if newsData[0].Type == "global" && (claims.UserType != models.DIRECTIVE && claims.UserType != models.DIRECTOR) {
+	if err := n.publishNews(findNews.ID); err != nil {
 		return &ErrorRes{
-			Err:        fmt.Errorf("Unauthorized"),
-			StatusCode: http.StatusUnauthorized,
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
 		}
 	}
-	if newsData[0].Type == "student" && claims.UserType != models.STUDENT_DIRECTIVE {
+	return nil
+}
+
+func (n *NewsService) ScheduleNews(
+	data forms.ScheduleNewsDTO,
+	id string,
+	claims *Claims,
+) *ErrorRes {
+	findNews, errRes := n.getNewsToManage(id, claims)
+	if errRes != nil {
+		return errRes
+	}
+	if findNews.State != models.NEWS_STATE_DRAFT && findNews.State != models.NEWS_STATE_SCHEDULED {
 		return &ErrorRes{
-			Err:        fmt.Errorf("Unauthorized"),
-			StatusCode: http.StatusUnauthorized,
+			Err:        fmt.Errorf("la noticia ya fue publicada"),
+			StatusCode: http.StatusConflict,
 		}
 	}
-	// Delete image
-	_, err = nats.Request("delete_image", []byte(newsData[0].Image.ID))
-	if err != nil {
+	if !data.PublishAt.After(time.Now()) {
 		return &ErrorRes{
-			Err:        err,
-			StatusCode: http.StatusNotFound,
+			Err:        fmt.Errorf("la fecha de publicación debe ser futura"),
+			StatusCode: http.StatusBadRequest,
 		}
 	}
-	// Delete news
-	newsModel.Use().FindOneAndUpdate(
+	_, err := newsModel.Use().UpdateOne(
 		db.Ctx,
 		bson.D{
 			{
 				Key:   "_id",
-				Value: idObjectId,
+				Value: findNews.ID,
 			},
 		},
 		bson.D{
 			{
 				Key: "$set",
-				Value: bson.D{
-					{
-						Key:   "status",
-						Value: false,
-					},
-					{
-						Key:   "body",
-						Value: "",
-					},
+				Value: bson.M{
+					"state":       models.NEWS_STATE_SCHEDULED,
+					"publish_at":  primitive.NewDateTimeFromTime(data.PublishAt),
+					"update_date": primitive.NewDateTimeFromTime(time.Now()),
+				},
+			},
+			{
+				Key: "$inc",
+				Value: bson.M{
+					"version": 1,
 				},
 			},
 		},
 	)
+	if err != nil {
+		return &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	invalidateNewsList()
 	return nil
 }
 
+// News go to the trash with their content, they are purged after the retention
+func (n *NewsService) DeleteNews(
+	id string,
+	ifMatch string,
+	claims *Claims,
+) (*models.News, *ErrorRes) {
+	version, errRes := getIfMatchVersion(ifMatch)
+	if errRes != nil {
+		return nil, errRes
+	}
+	findNews, errRes := n.getNewsToManage(id, claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	deletedBy, err := primitive.ObjectIDFromHex(claims.ID)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	filter := bson.D{
+		{
+			Key:   "_id",
+			Value: findNews.ID,
+		},
+	}
+	if version != 0 {
+		filter = append(filter, primitive.E{
+			Key:   "version",
+			Value: version,
+		})
+	}
+	result, err := newsModel.Use().UpdateOne(
+		db.Ctx,
+		filter,
+		bson.D{
+			{
+				Key: "$set",
+				Value: bson.M{
+					"status":     false,
+					"deleted_at": primitive.NewDateTimeFromTime(time.Now()),
+					"deleted_by": deletedBy,
+				},
+			},
+			{
+				Key: "$inc",
+				Value: bson.M{
+					"version": 1,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	if result.MatchedCount == 0 {
+		return n.getConflict(findNews.ID)
+	}
+	invalidateNewsList()
+	return nil, nil
+}
+
 func NewNewsService() *NewsService {
 	if newsService == nil {
 		newsService = &NewsService{}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Listing pages are cached for a short time, changes of other users
+// (reactions, comments) take at most this long to show up
+const NEWS_LIST_TTL = 30 * time.Second
+
+// Generations are renewed to invalidate every cached page at once
+const NEWS_LIST_GENERATION_TTL = 24 * time.Hour
+
+// Courses and levels of a user change rarely, they are requested to the
+// users service at most once per TTL
+const READER_TTL = 5 * time.Minute
+
+type NewsPage struct {
+	News       []NewsResponse `bson:"news"`
+	Total      int            `bson:"total"`
+	NextCursor string         `bson:"next_cursor"`
+	Validator  *Validator     `bson:"validator"`
+}
+
+func getImageURLKey(key string) string {
+	return fmt.Sprintf("image_url:%s", key)
+}
+
+// Only the keys without a cached URL are requested
+func getImageURLs(keys []string) ([]string, error) {
+	urls := make([]string, len(keys))
+	var missing []string
+	for i, key := range keys {
+		if url, ok := cacheStore.Get(getImageURLKey(key)); ok {
+			urls[i] = string(url)
+		} else {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return urls, nil
+	}
+	missingURLs, err := signImageURLs(missing)
+	if err != nil {
+		return nil, err
+	}
+	// Cached signed URLs must expire before the URLs themselves
+	j := 0
+	for i, key := range keys {
+		if urls[i] != "" {
+			continue
+		}
+		urls[i] = missingURLs[j]
+		cacheStore.Set(getImageURLKey(key), []byte(urls[i]), imageURLExpiry/2)
+		j++
+	}
+	return urls, nil
+}
+
+func getReaderKey(userObjectID primitive.ObjectID, userType string) string {
+	return fmt.Sprintf("reader:%s:%s", userObjectID.Hex(), userType)
+}
+
+func getCachedReader(userObjectID primitive.ObjectID, userType string) (*Reader, bool) {
+	value, ok := cacheStore.Get(getReaderKey(userObjectID, userType))
+	if !ok {
+		return nil, false
+	}
+	reader := &Reader{
+		Courses: []primitive.ObjectID{},
+		Levels:  []primitive.ObjectID{},
+	}
+	if err := bson.Unmarshal(value, reader); err != nil {
+		return nil, false
+	}
+	return reader, true
+}
+
+func setCachedReader(reader *Reader) {
+	value, err := bson.Marshal(reader)
+	if err != nil {
+		return
+	}
+	cacheStore.Set(getReaderKey(reader.ID, reader.UserType), value, READER_TTL)
+}
+
+func getNewsListGeneration(key string) string {
+	if generation, ok := cacheStore.Get(key); ok {
+		return string(generation)
+	}
+	return renewNewsListGeneration(key)
+}
+
+func renewNewsListGeneration(key string) string {
+	generation := strconv.FormatInt(time.Now().UnixNano(), 36)
+	cacheStore.Set(key, []byte(generation), NEWS_LIST_GENERATION_TTL)
+	return generation
+}
+
+func getUserGenerationKey(userObjectID primitive.ObjectID) string {
+	return fmt.Sprintf("news_list:generation:%s", userObjectID.Hex())
+}
+
+// Invalidates the cached pages of every user
+func invalidateNewsList() {
+	renewNewsListGeneration("news_list:generation")
+}
+
+// Invalidates the cached pages of a user, after changes that are
+// only visible to them (reads, own reaction)
+func invalidateUserNewsList(userObjectID primitive.ObjectID) {
+	renewNewsListGeneration(getUserGenerationKey(userObjectID))
+}
+
+func getNewsListKey(userObjectID primitive.ObjectID, params ...string) string {
+	hash := sha1.Sum([]byte(strings.Join(params, "\x00")))
+	return fmt.Sprintf(
+		"news_list:%s:%s:%s:%s",
+		getNewsListGeneration("news_list:generation"),
+		getNewsListGeneration(getUserGenerationKey(userObjectID)),
+		userObjectID.Hex(),
+		hex.EncodeToString(hash[:]),
+	)
+}
+
+func getCachedNewsList(key string) (*NewsPage, bool) {
+	value, ok := cacheStore.Get(key)
+	if !ok {
+		return nil, false
+	}
+	var page *NewsPage
+	if err := bson.Unmarshal(value, &page); err != nil {
+		return nil, false
+	}
+	return page, true
+}
+
+func setCachedNewsList(key string, page *NewsPage) {
+	value, err := bson.Marshal(page)
+	if err != nil {
+		return
+	}
+	cacheStore.Set(key, value, NEWS_LIST_TTL)
+}
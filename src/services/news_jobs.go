@@ -0,0 +1,123 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"github.com/CPU-commits/Intranet_BNews/src/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	PUBLISHER_INTERVAL = time.Minute
+	ARCHIVER_INTERVAL  = time.Minute
+)
+
+func (n *NewsService) publishScheduledNews() error {
+	opts := options.Find().SetProjection(bson.D{
+		{
+			Key:   "_id",
+			Value: 1,
+		},
+	})
+	cursor, err := newsModel.Use().Find(db.Ctx, bson.D{
+		{
+			Key:   "status",
+			Value: true,
+		},
+		{
+			Key:   "state",
+			Value: models.NEWS_STATE_SCHEDULED,
+		},
+		{
+			Key: "publish_at",
+			Value: bson.M{
+				"$lte": primitive.NewDateTimeFromTime(time.Now()),
+			},
+		},
+	}, opts)
+	if err != nil {
+		return err
+	}
+	var newsData []models.News
+	if err := cursor.All(db.Ctx, &newsData); err != nil {
+		return err
+	}
+	for _, news := range newsData {
+		if err := n.publishNews(news.ID); err != nil {
+			log.Printf("Error publishing news %s: %v\n", news.ID.Hex(), err)
+		}
+	}
+	return nil
+}
+
+func (n *NewsService) archiveExpiredNews() error {
+	result, err := newsModel.Use().UpdateMany(
+		db.Ctx,
+		bson.D{
+			{
+				Key:   "status",
+				Value: true,
+			},
+			{
+				Key:   "state",
+				Value: models.NEWS_STATE_PUBLISHED,
+			},
+			{
+				Key: "expires_at",
+				Value: bson.M{
+					"$lte": primitive.NewDateTimeFromTime(time.Now()),
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$set",
+				Value: bson.M{
+					"state":         models.NEWS_STATE_ARCHIVED,
+					"activity_date": primitive.NewDateTimeFromTime(time.Now()),
+				},
+			},
+			{
+				Key: "$inc",
+				Value: bson.M{
+					"version": 1,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.ModifiedCount > 0 {
+		invalidateNewsList()
+	}
+	return nil
+}
+
+// Publish scheduled news when its publish date has arrived
+func (n *NewsService) PublishScheduledNews() {
+	go func() {
+		ticker := time.NewTicker(PUBLISHER_INTERVAL)
+		for range ticker.C {
+			if err := n.publishScheduledNews(); err != nil {
+				log.Printf("Error publishing scheduled news: %v\n", err)
+			}
+		}
+	}()
+}
+
+// Archive published news when its expiry date has passed
+func (n *NewsService) ArchiveExpiredNews() {
+	go func() {
+		ticker := time.NewTicker(ARCHIVER_INTERVAL)
+		for range ticker.C {
+			if err := n.archiveExpiredNews(); err != nil {
+				log.Printf("Error archiving expired news: %v\n", err)
+			}
+		}
+	}()
+}
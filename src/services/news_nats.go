@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"reflect"
 
-	"github.com/CPU-commits/Intranet_BNews/src/db"
 	"github.com/CPU-commits/Intranet_BNews/src/forms"
 	"github.com/CPU-commits/Intranet_BNews/src/res"
 	"github.com/CPU-commits/Intranet_BNews/src/stack"
@@ -40,10 +39,10 @@ func (n *NewsService) UploadNews() {
 			return
 		}
 		// Upload
-		_, err = newsModel.Use().InsertOne(db.Ctx, modelNews)
-		if err != nil {
+		if _, err := n.insertNews(modelNews); err != nil {
 			return
 		}
+		invalidateNewsList()
 		// Notify news
 		nats.PublishEncode("notify/global", &res.Notify{
 			Title: payload["title"].(string),
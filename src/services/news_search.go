@@ -0,0 +1,244 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/CPU-commits/Intranet_BNews/src/models"
+	"github.com/CPU-commits/Intranet_BNews/src/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	SNIPPET_LENGTH  = 200
+	SNIPPET_CONTEXT = 60
+)
+
+var htmlTagRegexp = regexp.MustCompile(`<[^>]*>`)
+var wordRegexp = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+var accentsReplacer = strings.NewReplacer(
+	"á", "a",
+	"é", "e",
+	"í", "i",
+	"ó", "o",
+	"ú", "u",
+	"ü", "u",
+	"ñ", "n",
+)
+
+func normalizeWord(word string) string {
+	return accentsReplacer.Replace(strings.ToLower(word))
+}
+
+// Terms of the query reduced to a prefix, so they match the
+// words that MongoDB considered equal after stemming
+func getSearchTerms(q string) []string {
+	var terms []string
+	for _, word := range strings.Fields(q) {
+		if strings.HasPrefix(word, "-") {
+			continue
+		}
+		for _, term := range wordRegexp.FindAllString(word, -1) {
+			runes := []rune(normalizeWord(term))
+			if len(runes) > 5 {
+				runes = runes[:len(runes)-2]
+			} else if len(runes) > 3 {
+				runes = runes[:len(runes)-1]
+			}
+			terms = append(terms, string(runes))
+		}
+	}
+	return terms
+}
+
+func matchTerms(word string, terms []string) bool {
+	normalized := normalizeWord(word)
+	for _, term := range terms {
+		if strings.HasPrefix(normalized, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// Fragment of the text around the first match, escaped, with the
+// matching words between <mark> tags
+func getSnippet(text string, terms []string) string {
+	text = strings.Join(strings.Fields(htmlTagRegexp.ReplaceAllString(text, " ")), " ")
+	words := wordRegexp.FindAllStringIndex(text, -1)
+	if len(words) == 0 {
+		return ""
+	}
+	// Window
+	first := -1
+	for i, word := range words {
+		if matchTerms(text[word[0]:word[1]], terms) {
+			first = i
+			break
+		}
+	}
+	startWord := 0
+	if first != -1 {
+		startWord = first
+		for startWord > 0 && words[first][0]-words[startWord-1][0] <= SNIPPET_CONTEXT {
+			startWord--
+		}
+	}
+	start := words[startWord][0]
+	end := start
+	for i := startWord; i < len(words) && words[i][1]-start <= SNIPPET_LENGTH; i++ {
+		end = words[i][1]
+	}
+	if end == start {
+		end = words[startWord][1]
+	}
+	// Highlight
+	var snippet strings.Builder
+	if start > 0 {
+		snippet.WriteString("…")
+	}
+	last := start
+	for i := startWord; i < len(words) && words[i][1] <= end; i++ {
+		word := text[words[i][0]:words[i][1]]
+		if !matchTerms(word, terms) {
+			continue
+		}
+		snippet.WriteString(html.EscapeString(text[last:words[i][0]]))
+		snippet.WriteString("<mark>")
+		snippet.WriteString(html.EscapeString(word))
+		snippet.WriteString("</mark>")
+		last = words[i][1]
+	}
+	snippet.WriteString(html.EscapeString(text[last:end]))
+	if end < len(text) {
+		snippet.WriteString("…")
+	}
+	return snippet.String()
+}
+
+func (n *NewsService) SearchNews(
+	q string,
+	skip string,
+	limit string,
+	claims *Claims,
+) ([]NewsResponse, *ErrorRes) {
+	if len(strings.TrimSpace(q)) < 2 {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("la búsqueda debe tener al menos 2 caracteres"),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	skipNumber, limitNumber, err := utils.ParseSkipLimit(skip, limit)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	reader, errRes := getReader(claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	// Same visibility of a single news
+	matchStage := bson.D{
+		{
+			Key: "$match",
+			Value: bson.M{
+				"$text": bson.M{
+					"$search": q,
+				},
+				"status": true,
+				"type": bson.M{
+					"$in": reader.getNewsTypes(),
+				},
+				"$and": bson.A{
+					reader.getFilterAudience(),
+				},
+				"$or": bson.A{
+					bson.M{
+						"state": bson.M{
+							"$in": bson.A{
+								models.NEWS_STATE_PUBLISHED,
+								models.NEWS_STATE_ARCHIVED,
+							},
+						},
+					},
+					bson.M{
+						"author_id": reader.ID,
+					},
+				},
+			},
+		},
+	}
+	sortStage := bson.D{
+		{
+			Key: "$sort",
+			Value: bson.D{
+				{
+					Key: "score",
+					Value: bson.M{
+						"$meta": "textScore",
+					},
+				},
+				{Key: "upload_date", Value: -1},
+			},
+		},
+	}
+	skipStage := bson.D{
+		{
+			Key:   "$skip",
+			Value: skipNumber,
+		},
+	}
+	limitStage := bson.D{
+		{
+			Key:   "$limit",
+			Value: limitNumber,
+		},
+	}
+	projectStage := n.getProjectListStage()
+	project := projectStage[0].Value.(bson.M)
+	project["body"] = 1
+	project["score"] = bson.M{
+		"$meta": "textScore",
+	}
+	newsData, err := n.getNews(mongo.Pipeline{
+		matchStage,
+		sortStage,
+		skipStage,
+		limitStage,
+		n.getLookupFile(),
+		n.getLookupUser(),
+		n.getLookupCategory(),
+		n.getLookupReaction(reader.ID),
+		n.getLookupRead(reader.ID),
+		n.getLookupAcknowledgement(reader.ID),
+		projectStage,
+	}, true)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	if newsData == nil {
+		return newsData, nil
+	}
+	// Snippets
+	terms := getSearchTerms(q)
+	for i := range newsData {
+		newsData[i].Snippet = getSnippet(newsData[i].Body, terms)
+		if !strings.Contains(newsData[i].Snippet, "<mark>") {
+			if snippet := getSnippet(newsData[i].Headline, terms); strings.Contains(snippet, "<mark>") {
+				newsData[i].Snippet = snippet
+			}
+		}
+		newsData[i].Body = ""
+	}
+	return newsData, nil
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"github.com/CPU-commits/Intranet_BNews/src/models"
+	"github.com/CPU-commits/Intranet_BNews/src/settings"
+	"github.com/CPU-commits/Intranet_BNews/src/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Days deleted news stay in the trash, overridable by the TRASH_DAYS env
+const DEFAULT_TRASH_DAYS = 30
+
+const PURGER_INTERVAL = time.Hour
+
+func getTrashRetention() time.Duration {
+	days, err := strconv.Atoi(settings.GetSettings().TRASH_DAYS)
+	if err != nil || days <= 0 {
+		days = DEFAULT_TRASH_DAYS
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// News the user can manage, the same of verifyIdentity
+func (n *NewsService) getFilterManageable(claims *Claims) (bson.M, *ErrorRes) {
+	switch claims.UserType {
+	case models.DIRECTOR, models.DIRECTIVE:
+		return bson.M{
+			"type": "global",
+		}, nil
+	case models.STUDENT_DIRECTIVE:
+		return bson.M{
+			"type": "student",
+		}, nil
+	case models.TEACHER:
+		userObjectID, err := primitive.ObjectIDFromHex(claims.ID)
+		if err != nil {
+			return nil, &ErrorRes{
+				Err:        err,
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+		return bson.M{
+			"type":      "global",
+			"author_id": userObjectID,
+		}, nil
+	}
+	return nil, &ErrorRes{
+		Err:        fmt.Errorf("Unauthorized"),
+		StatusCode: http.StatusUnauthorized,
+	}
+}
+
+func (n *NewsService) GetTrash(
+	skip string,
+	limit string,
+	claims *Claims,
+) ([]NewsResponse, *ErrorRes) {
+	skipNumber, limitNumber, err := utils.ParseSkipLimit(skip, limit)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	filter, errRes := n.getFilterManageable(claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	filter["status"] = false
+	filter["deleted_at"] = bson.M{
+		"$exists": true,
+	}
+	projectStage := n.getProjectListStage()
+	project := projectStage[0].Value.(bson.M)
+	project["deleted_at"] = 1
+	newsData, err := n.getNews(mongo.Pipeline{
+		bson.D{
+			{
+				Key:   "$match",
+				Value: filter,
+			},
+		},
+		bson.D{
+			{
+				Key: "$sort",
+				Value: bson.D{
+					{Key: "deleted_at", Value: -1},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key:   "$skip",
+				Value: skipNumber,
+			},
+		},
+		bson.D{
+			{
+				Key:   "$limit",
+				Value: limitNumber,
+			},
+		},
+		n.getLookupFile(),
+		n.getLookupUser(),
+		n.getLookupCategory(),
+		projectStage,
+	}, false)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return newsData, nil
+}
+
+func (n *NewsService) RestoreNews(id string, claims *Claims) *ErrorRes {
+	findNews, errRes := n.getTrashedNewsToManage(id, claims)
+	if errRes != nil {
+		return errRes
+	}
+	if findNews.DeletedAt == 0 {
+		return &ErrorRes{
+			Err:        fmt.Errorf("la noticia no está en la papelera"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	_, err := newsModel.Use().UpdateOne(
+		db.Ctx,
+		bson.D{
+			{
+				Key:   "_id",
+				Value: findNews.ID,
+			},
+		},
+		bson.D{
+			{
+				Key: "$set",
+				Value: bson.M{
+					"status": true,
+				},
+			},
+			{
+				Key: "$inc",
+				Value: bson.M{
+					"version": 1,
+				},
+			},
+			{
+				Key: "$unset",
+				Value: bson.M{
+					"deleted_at": "",
+					"deleted_by": "",
+				},
+			},
+		},
+	)
+	if err != nil {
+		return &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	invalidateNewsList()
+	return nil
+}
+
+// Remove the news, its images and everything related to it. Removing the
+// news is the step that can only happen once, images are released after
+// it so a purge that failed and is tried again never releases them twice
+func (n *NewsService) purgeNews(newsData *models.News) error {
+	images := []primitive.ObjectID{newsData.Img}
+	imagesRevisions, err := revisionsModel.Use().Distinct(db.Ctx, "img", bson.D{
+		{
+			Key:   "news",
+			Value: newsData.ID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, image := range imagesRevisions {
+		if imageObjectId, ok := image.(primitive.ObjectID); ok && imageObjectId != newsData.Img {
+			images = append(images, imageObjectId)
+		}
+	}
+	result, err := newsModel.Use().DeleteOne(db.Ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: newsData.ID,
+		},
+		{
+			Key:   "status",
+			Value: false,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return nil
+	}
+	// The news is gone, every step is tried and the first error returned
+	var firstErr error
+	for _, image := range images {
+		if err := releaseImage(image); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	filter := bson.D{
+		{
+			Key:   "news",
+			Value: newsData.ID,
+		},
+	}
+	related := []*mongo.Collection{
+		reactionsModel.Use(),
+		commentsModel.Use(),
+		readsModel.Use(),
+		acknowledgementsModel.Use(),
+		revisionsModel.Use(),
+	}
+	for _, collection := range related {
+		if _, err := collection.DeleteMany(db.Ctx, filter); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (n *NewsService) purgeTrash() error {
+	cursor, err := newsModel.Use().Find(db.Ctx, bson.D{
+		{
+			Key:   "status",
+			Value: false,
+		},
+		{
+			Key: "deleted_at",
+			Value: bson.M{
+				"$lte": primitive.NewDateTimeFromTime(time.Now().Add(-getTrashRetention())),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	var newsData []models.News
+	if err := cursor.All(db.Ctx, &newsData); err != nil {
+		return err
+	}
+	for i := range newsData {
+		if err := n.purgeNews(&newsData[i]); err != nil {
+			log.Printf("Error purging news %s: %v\n", newsData[i].ID.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// Permanently remove news in the trash after the retention
+func (n *NewsService) PurgeTrash() {
+	go func() {
+		ticker := time.NewTicker(PURGER_INTERVAL)
+		for range ticker.C {
+			if err := n.purgeTrash(); err != nil {
+				log.Printf("Error purging trash: %v\n", err)
+			}
+		}
+	}()
+}
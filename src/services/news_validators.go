@@ -0,0 +1,72 @@
+package services
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Validators of a response for conditional requests
+type Validator struct {
+	ETag         string    `bson:"etag"`
+	LastModified time.Time `bson:"last_modified"`
+}
+
+// If-None-Match has precedence over If-Modified-Since
+func (v *Validator) NotModified(ifNoneMatch string, ifModifiedSince string) bool {
+	if ifNoneMatch != "" {
+		for _, etag := range strings.Split(ifNoneMatch, ",") {
+			etag = strings.TrimSpace(etag)
+			if etag == "*" || strings.TrimPrefix(etag, "W/") == strings.TrimPrefix(v.ETag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+	if ifModifiedSince == "" || v.LastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	return !v.LastModified.Truncate(time.Second).After(since)
+}
+
+// Validator of the served news. The ETag is the hash of the response and
+// Last-Modified the last change of the news, their counters or the state
+// of the user. Signed image URLs expire, so responses are modified at
+// least as often as their URLs are signed again
+func newNewsValidator(newsData []NewsResponse, extra ...interface{}) (*Validator, error) {
+	data, err := json.Marshal([]interface{}{newsData, extra})
+	if err != nil {
+		return nil, err
+	}
+	hash := sha1.Sum(data)
+	lastModified := primitive.NewDateTimeFromTime(time.Now().Truncate(imageURLExpiry / 2))
+	for _, news := range newsData {
+		if news.ModifiedDate > lastModified {
+			lastModified = news.ModifiedDate
+		}
+	}
+	return &Validator{
+		ETag:         fmt.Sprintf("\"%s\"", hex.EncodeToString(hash[:])),
+		LastModified: lastModified.Time(),
+	}, nil
+}
+
+// The ETag of a single news starts with its version, for If-Match
+func newSingleNewsValidator(news *NewsResponse) (*Validator, error) {
+	validator, err := newNewsValidator([]NewsResponse{*news})
+	if err != nil {
+		return nil, err
+	}
+	validator.ETag = fmt.Sprintf("\"%d-%s", news.Version, strings.TrimPrefix(validator.ETag, "\""))
+	return validator, nil
+}
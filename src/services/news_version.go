@@ -0,0 +1,58 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"github.com/CPU-commits/Intranet_BNews/src/models"
+	"github.com/CPU-commits/Intranet_BNews/src/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Entity tag of a version of a news
+func NewsETag(version int) string {
+	return fmt.Sprintf("\"%d\"", version)
+}
+
+// Version required by an If-Match header, 0 if any version matches.
+// Weak tags do not match any version
+func getIfMatchVersion(ifMatch string) (int, *ErrorRes) {
+	version, err := utils.ParseIfMatchVersion(ifMatch)
+	if err == utils.ErrWeakIfMatch {
+		return 0, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusPreconditionFailed,
+		}
+	}
+	if err != nil {
+		return 0, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	return version, nil
+}
+
+// The news was modified since the version the user has, the current
+// version is returned so the user can merge their changes
+func (n *NewsService) getConflict(idObjectId primitive.ObjectID) (*models.News, *ErrorRes) {
+	var current *models.News
+	err := newsModel.Use().FindOne(db.Ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: idObjectId,
+		},
+	}).Decode(&current)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("noticia no encontrada"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	return current, &ErrorRes{
+		Err:        fmt.Errorf("la noticia fue modificada por otro usuario"),
+		StatusCode: http.StatusPreconditionFailed,
+	}
+}
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"github.com/CPU-commits/Intranet_BNews/src/models"
+	"github.com/CPU-commits/Intranet_BNews/src/settings"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Allowed reactions as name:emoji pairs, overridable by the REACTIONS env
+const DEFAULT_REACTIONS = "like:👍,love:❤️,haha:😂,wow:😮,sad:😢"
+
+var reactionsService *ReactionsService
+
+type ReactionsService struct {
+	allowed []ReactionResponse
+}
+
+func parseReactions(reactions string) []ReactionResponse {
+	var allowed []ReactionResponse
+	for _, reaction := range strings.Split(reactions, ",") {
+		values := strings.SplitN(strings.TrimSpace(reaction), ":", 2)
+		if len(values) != 2 || values[0] == "" {
+			continue
+		}
+		allowed = append(allowed, ReactionResponse{
+			Name:  values[0],
+			Emoji: values[1],
+		})
+	}
+	return allowed
+}
+
+func (r *ReactionsService) isAllowed(reaction string) bool {
+	for _, allowed := range r.allowed {
+		if allowed.Name == reaction {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ReactionsService) GetReactions() []ReactionResponse {
+	return r.allowed
+}
+
+func (r *ReactionsService) incrementReactions(newsObjectId primitive.ObjectID, increments bson.M) error {
+	_, err := newsModel.Use().UpdateOne(
+		db.Ctx,
+		bson.D{
+			{
+				Key:   "_id",
+				Value: newsObjectId,
+			},
+		},
+		bson.D{
+			{
+				Key:   "$inc",
+				Value: increments,
+			},
+			{
+				Key: "$set",
+				Value: bson.M{
+					"activity_date": primitive.NewDateTimeFromTime(time.Now()),
+				},
+			},
+		},
+	)
+	return err
+}
+
+func (r *ReactionsService) setReaction(
+	newsObjectId primitive.ObjectID,
+	userObjectID primitive.ObjectID,
+	reaction string,
+) *ErrorRes {
+	filter := bson.D{
+		{
+			Key:   "user",
+			Value: userObjectID,
+		},
+		{
+			Key:   "news",
+			Value: newsObjectId,
+		},
+	}
+	newReaction := reactionsModel.NewModel(userObjectID, newsObjectId, reaction)
+	update := bson.D{
+		{
+			Key: "$set",
+			Value: bson.M{
+				"reaction": newReaction.Reaction,
+				"date":     newReaction.Date,
+			},
+		},
+	}
+	// Previous reaction of the user, if any
+	var previous *models.Reactions
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.Before)
+	err := reactionsModel.Use().FindOneAndUpdate(db.Ctx, filter, update, opts).Decode(&previous)
+	if mongo.IsDuplicateKeyError(err) {
+		// A concurrent request inserted the reaction, now it exists
+		err = reactionsModel.Use().FindOneAndUpdate(db.Ctx, filter, update, opts).Decode(&previous)
+	}
+	if err != nil && err != mongo.ErrNoDocuments {
+		return &ErrorRes{
+			StatusCode: http.StatusServiceUnavailable,
+			Err:        err,
+		}
+	}
+	// Counters
+	increments := bson.M{}
+	if previous != nil {
+		if previous.Reaction == reaction {
+			return nil
+		}
+		increments["reactions."+previous.Reaction] = -1
+	}
+	increments["reactions."+reaction] = 1
+	if err := r.incrementReactions(newsObjectId, increments); err != nil {
+		return &ErrorRes{
+			StatusCode: http.StatusServiceUnavailable,
+			Err:        err,
+		}
+	}
+	invalidateUserNewsList(userObjectID)
+	return nil
+}
+
+func (r *ReactionsService) clearReaction(
+	newsObjectId primitive.ObjectID,
+	userObjectID primitive.ObjectID,
+) *ErrorRes {
+	var previous *models.Reactions
+	err := reactionsModel.Use().FindOneAndDelete(db.Ctx, bson.D{
+		{
+			Key:   "user",
+			Value: userObjectID,
+		},
+		{
+			Key:   "news",
+			Value: newsObjectId,
+		},
+	}).Decode(&previous)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return &ErrorRes{
+			StatusCode: http.StatusServiceUnavailable,
+			Err:        err,
+		}
+	}
+	err = r.incrementReactions(newsObjectId, bson.M{
+		"reactions." + previous.Reaction: -1,
+	})
+	if err != nil {
+		return &ErrorRes{
+			StatusCode: http.StatusServiceUnavailable,
+			Err:        err,
+		}
+	}
+	invalidateUserNewsList(userObjectID)
+	return nil
+}
+
+func (r *ReactionsService) SetReaction(
+	idNews string,
+	reaction string,
+	claims *Claims,
+) *ErrorRes {
+	if !r.isAllowed(reaction) {
+		return &ErrorRes{
+			Err:        fmt.Errorf("reacción no permitida"),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	newsObjectId, userObjectID, errRes := getVisibleNewsIds(idNews, claims)
+	if errRes != nil {
+		return errRes
+	}
+	return r.setReaction(newsObjectId, userObjectID, reaction)
+}
+
+func (r *ReactionsService) ClearReaction(
+	idNews string,
+	claims *Claims,
+) *ErrorRes {
+	newsObjectId, userObjectID, errRes := getVisibleNewsIds(idNews, claims)
+	if errRes != nil {
+		return errRes
+	}
+	return r.clearReaction(newsObjectId, userObjectID)
+}
+
+// Toggle the like reaction, it replaces any other reaction of the user
+func (r *ReactionsService) LikeNews(
+	idNews string,
+	claims *Claims,
+) *ErrorRes {
+	newsObjectId, userObjectID, errRes := getVisibleNewsIds(idNews, claims)
+	if errRes != nil {
+		return errRes
+	}
+	var current *models.Reactions
+	reactionsModel.Use().FindOne(db.Ctx, bson.D{
+		{
+			Key:   "user",
+			Value: userObjectID,
+		},
+		{
+			Key:   "news",
+			Value: newsObjectId,
+		},
+	}).Decode(&current)
+	if current != nil && current.Reaction == models.REACTION_LIKE {
+		return r.clearReaction(newsObjectId, userObjectID)
+	}
+	return r.setReaction(newsObjectId, userObjectID, models.REACTION_LIKE)
+}
+
+// Recompute the reaction counters of the news from the reactions collection
+func (r *ReactionsService) ReconcileReactions() error {
+	_, err := newsModel.Use().Aggregate(db.Ctx, mongo.Pipeline{
+		bson.D{
+			{
+				Key: "$lookup",
+				Value: bson.M{
+					"from":         models.REACTIONS_COLLECTION,
+					"localField":   "_id",
+					"foreignField": "news",
+					"as":           "counts",
+					"pipeline": bson.A{
+						bson.M{
+							"$group": bson.M{
+								"_id": "$reaction",
+								"count": bson.M{
+									"$sum": 1,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$project",
+				Value: bson.M{
+					"reactions": bson.M{
+						"$arrayToObject": bson.M{
+							"$map": bson.M{
+								"input": "$counts",
+								"in": bson.M{
+									"k": "$$this._id",
+									"v": "$$this.count",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$merge",
+				Value: bson.M{
+					"into":           models.NEWS_COLLECTION,
+					"on":             "_id",
+					"whenMatched":    "merge",
+					"whenNotMatched": "discard",
+				},
+			},
+		},
+	})
+	return err
+}
+
+func NewReactionsService() *ReactionsService {
+	if reactionsService == nil {
+		reactions := settings.GetSettings().REACTIONS
+		if reactions == "" {
+			reactions = DEFAULT_REACTIONS
+		}
+		reactionsService = &ReactionsService{
+			allowed: parseReactions(reactions),
+		}
+	}
+	return reactionsService
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"github.com/CPU-commits/Intranet_BNews/src/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var readsService *ReadsService
+
+type ReadsService struct{}
+
+// Keeps the date of the first read
+func (r *ReadsService) markRead(newsObjectId, userObjectID primitive.ObjectID) error {
+	read := readsModel.NewModel(userObjectID, newsObjectId)
+	opts := options.Update().SetUpsert(true)
+	result, err := readsModel.Use().UpdateOne(
+		db.Ctx,
+		bson.D{
+			{
+				Key:   "news",
+				Value: newsObjectId,
+			},
+			{
+				Key:   "user",
+				Value: userObjectID,
+			},
+		},
+		bson.D{
+			{
+				Key:   "$setOnInsert",
+				Value: read,
+			},
+		},
+		opts,
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if result.UpsertedCount > 0 {
+		invalidateUserNewsList(userObjectID)
+	}
+	return nil
+}
+
+// Published news visible to the user that they have not read
+func (r *ReadsService) getUnreadPipeline(newsType string, reader *Reader) mongo.Pipeline {
+	filter := newsService.getFilterStatusTrue(newsType, reader)
+	delete(filter, "$or")
+	filter["state"] = models.NEWS_STATE_PUBLISHED
+	return mongo.Pipeline{
+		bson.D{
+			{
+				Key:   "$match",
+				Value: filter,
+			},
+		},
+		newsService.getLookupRead(reader.ID),
+		bson.D{
+			{
+				Key: "$match",
+				Value: bson.M{
+					"own_read": bson.M{
+						"$size": 0,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ReadsService) MarkAsRead(idNews string, claims *Claims) *ErrorRes {
+	newsObjectId, userObjectID, errRes := getVisibleNewsIds(idNews, claims)
+	if errRes != nil {
+		return errRes
+	}
+	if err := r.markRead(newsObjectId, userObjectID); err != nil {
+		return &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return nil
+}
+
+func (r *ReadsService) MarkAllAsRead(newsType string, claims *Claims) *ErrorRes {
+	reader, errRes := getReader(claims)
+	if errRes != nil {
+		return errRes
+	}
+	if !reader.canSeeType(newsType) {
+		return &ErrorRes{
+			Err:        fmt.Errorf("no tienes acceso a estas noticias"),
+			StatusCode: http.StatusUnauthorized,
+		}
+	}
+	pipeline := r.getUnreadPipeline(newsType, reader)
+	pipeline = append(
+		pipeline,
+		bson.D{
+			{
+				Key: "$project",
+				Value: bson.M{
+					"_id":  0,
+					"news": "$_id",
+					"user": reader.ID,
+					"date": primitive.NewDateTimeFromTime(time.Now()),
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$merge",
+				Value: bson.M{
+					"into":           models.READS_COLLECTION,
+					"on":             bson.A{"news", "user"},
+					"whenMatched":    "keepExisting",
+					"whenNotMatched": "insert",
+				},
+			},
+		},
+	)
+	_, err := newsModel.Use().Aggregate(db.Ctx, pipeline)
+	if err != nil {
+		return &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	invalidateUserNewsList(reader.ID)
+	return nil
+}
+
+func (r *ReadsService) GetUnreadCount(newsType string, claims *Claims) (int, *ErrorRes) {
+	reader, errRes := getReader(claims)
+	if errRes != nil {
+		return 0, errRes
+	}
+	if !reader.canSeeType(newsType) {
+		return 0, &ErrorRes{
+			Err:        fmt.Errorf("no tienes acceso a estas noticias"),
+			StatusCode: http.StatusUnauthorized,
+		}
+	}
+	pipeline := r.getUnreadPipeline(newsType, reader)
+	pipeline = append(pipeline, bson.D{
+		{
+			Key:   "$count",
+			Value: "unread",
+		},
+	})
+	cursor, err := newsModel.Use().Aggregate(db.Ctx, pipeline)
+	if err != nil {
+		return 0, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	var counts []struct {
+		Unread int `bson:"unread"`
+	}
+	if err = cursor.All(db.Ctx, &counts); err != nil {
+		return 0, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	if len(counts) == 0 {
+		return 0, nil
+	}
+	return counts[0].Unread, nil
+}
+
+func NewReadsService() *ReadsService {
+	if readsService == nil {
+		readsService = &ReadsService{}
+	}
+	return readsService
+}
@@ -14,23 +14,138 @@ type UploadNewsNats struct {
 }
 
 type Image struct {
-	ID  string `json:"_id" bson:"_id" example:"638660ca141aa4ee9faf07e8"`
-	URL string `json:"url" bson:"url" example:"https://repository.com/file/$dsK2!1"`
-	Key string `bson:"key" example:"$dsK2!1"`
+	ID       string         `json:"_id" bson:"_id" example:"638660ca141aa4ee9faf07e8"`
+	URL      string         `json:"url" bson:"url" example:"https://repository.com/file/$dsK2!1"`
+	Key      string         `bson:"key" example:"$dsK2!1"`
+	Width    int            `json:"width,omitempty" bson:"width,omitempty" extensions:"x-omitempty" example:"1920"`
+	Height   int            `json:"height,omitempty" bson:"height,omitempty" extensions:"x-omitempty" example:"1080"`
+	BlurHash string         `json:"blurhash,omitempty" bson:"blurhash,omitempty" extensions:"x-omitempty" example:"LpDdPVBUwxX9m0WEjte=gJfjfQfj"`
+	Color    string         `json:"color,omitempty" bson:"color,omitempty" extensions:"x-omitempty" example:"#0816c8"`
+	Variants []ImageVariant `json:"variants" bson:"variants"`
+	SrcSet   string         `json:"srcset" bson:"srcset,omitempty" example:"https://repository.com/file/$dsK2!1 320w, https://repository.com/file/$dsK2!2 640w"`
+}
+
+type ImageVariant struct {
+	Name   string `json:"name" bson:"name" example:"card" enum:"thumbnail,card,full"`
+	Width  int    `json:"width" bson:"width" example:"640"`
+	Height int    `json:"height" bson:"height" example:"360"`
+	URL    string `json:"url" bson:"url,omitempty" example:"https://repository.com/file/$dsK2!2"`
+	Key    string `json:"-" bson:"key"`
 }
 
 type NewsResponse struct {
-	Author     models.User        `json:"author,omitempty" bson:"author,omitempty" extensions:"x-omitempty"`
-	Headline   string             `json:"headline" bson:"headline" example:"Example..."`
-	Title      string             `json:"title" bson:"title" example:"Title !!"`
-	Image      Image              `json:"image" bson:"image"`
-	UploadDate primitive.DateTime `json:"upload_date" bson:"upload_date" swaggertype:"string" example:"2022-09-21T20:10:23.309+00:00"`
-	UpdateDate primitive.DateTime `json:"update_date" bson:"update:date" swaggertype:"string" example:"2022-09-21T20:10:23.309+00:00"`
-	URL        string             `json:"url" bson:"url" example:"title"`
-	Type       string             `json:"type" bson:"type" example:"global" enum:"global,student"`
-	Body       string             `json:"body" bson:"body" example:"This is a body..."`
-	Status     bool               `json:"status" bson:"status"`
-	Like       bool               `json:"like"`
-	Likes      int                `json:"likes" example:"10"`
+	Author           models.User        `json:"author,omitempty" bson:"author,omitempty" extensions:"x-omitempty"`
+	Headline         string             `json:"headline" bson:"headline" example:"Example..."`
+	Title            string             `json:"title" bson:"title" example:"Title !!"`
+	Image            Image              `json:"image" bson:"image"`
+	UploadDate       primitive.DateTime `json:"upload_date" bson:"upload_date" swaggertype:"string" example:"2022-09-21T20:10:23.309+00:00"`
+	UpdateDate       primitive.DateTime `json:"update_date" bson:"update_date" swaggertype:"string" example:"2022-09-21T20:10:23.309+00:00"`
+	URL              string             `json:"url" bson:"url" example:"title"`
+	Type             string             `json:"type" bson:"type" example:"global" enum:"global,student"`
+	Body             string             `json:"body" bson:"body" example:"This is a body..."`
+	Status           bool               `json:"status" bson:"status"`
+	State            string             `json:"state" bson:"state" example:"published" enum:"draft,scheduled,published,archived"`
+	PublishAt        primitive.DateTime `json:"publish_at,omitempty" bson:"publish_at,omitempty" swaggertype:"string" extensions:"x-omitempty" example:"2022-09-21T20:10:23.309+00:00"`
+	ExpiresAt        primitive.DateTime `json:"expires_at,omitempty" bson:"expires_at,omitempty" swaggertype:"string" extensions:"x-omitempty" example:"2022-10-21T20:10:23.309+00:00"`
+	Like             bool               `json:"like" bson:"like"`
+	Likes            int                `json:"likes" bson:"likes" example:"10"`
+	Reactions        map[string]int     `json:"reactions" bson:"reactions"`
+	Reaction         string             `json:"reaction,omitempty" bson:"reaction,omitempty" extensions:"x-omitempty" example:"like"`
+	Read             bool               `json:"read" bson:"read"`
+	RequiresAck      bool               `json:"requires_ack" bson:"requires_ack"`
+	Acknowledged     bool               `json:"acknowledged" bson:"acknowledged"`
+	Comments         int                `json:"comments" bson:"comments" example:"3"`
+	CommentsDisabled bool               `json:"comments_disabled" bson:"comments_disabled"`
+	Category         *CategoryResponse  `json:"category,omitempty" bson:"category,omitempty" extensions:"x-omitempty"`
+	Tags             []string           `json:"tags" bson:"tags" example:"matrícula,2023"`
+	Audience         *models.Audience   `json:"audience,omitempty" bson:"audience,omitempty" extensions:"x-omitempty"`
+	Version          int                `json:"version" bson:"version" example:"1"`
+	ModifiedDate     primitive.DateTime `json:"-" bson:"modified_date,omitempty"`
+	DeletedAt        primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at,omitempty" swaggertype:"string" extensions:"x-omitempty" example:"2022-09-21T20:10:23.309+00:00"`
+	Score            float64            `json:"score,omitempty" bson:"score,omitempty" extensions:"x-omitempty" example:"1.5"`
+	Snippet          string             `json:"snippet,omitempty" bson:"-" extensions:"x-omitempty" example:"...la <mark>matrícula</mark> 2023..."`
+	ID               string             `json:"_id" bson:"_id" example:"638660ca141aa4ee9faf07e8"`
+}
+
+type UploadURLResponse struct {
+	URL       string             `json:"url" example:"https://bucket.s3.us-east-1.amazonaws.com"`
+	Key       string             `json:"key" example:"news/1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed.jpg"`
+	Fields    map[string]string  `json:"fields"`
+	ExpiresAt primitive.DateTime `json:"expires_at" swaggertype:"string" example:"2022-09-21T20:10:23.309+00:00"`
+}
+
+type CategoryResponse struct {
+	ID   string `json:"_id" bson:"_id" example:"638660ca141aa4ee9faf07e8"`
+	Name string `json:"name" bson:"name" example:"Deportes"`
+	Slug string `json:"slug" bson:"slug" example:"deportes"`
+}
+
+type TagResponse struct {
+	Tag   string `json:"tag" bson:"_id" example:"matrícula"`
+	Count int    `json:"count" bson:"count" example:"4"`
+}
+
+type ReactionResponse struct {
+	Name  string `json:"name" example:"like"`
+	Emoji string `json:"emoji" example:"👍"`
+}
+
+type CommentResponse struct {
 	ID         string             `json:"_id" bson:"_id" example:"638660ca141aa4ee9faf07e8"`
+	Author     models.User        `json:"author" bson:"author"`
+	Parent     string             `json:"parent,omitempty" bson:"parent,omitempty" extensions:"x-omitempty" example:"638660ca141aa4ee9faf07e8"`
+	Body       string             `json:"body" bson:"body" example:"Great!"`
+	Hidden     bool               `json:"hidden" bson:"hidden"`
+	Replies    int                `json:"replies" bson:"replies" example:"2"`
+	UploadDate primitive.DateTime `json:"upload_date" bson:"upload_date" swaggertype:"string" example:"2022-09-21T20:10:23.309+00:00"`
+	UpdateDate primitive.DateTime `json:"update_date" bson:"update_date" swaggertype:"string" example:"2022-09-21T20:10:23.309+00:00"`
+}
+
+type AcknowledgementResponse struct {
+	User models.User        `json:"user" bson:"user"`
+	Date primitive.DateTime `json:"date" bson:"date" swaggertype:"string" example:"2022-09-21T20:10:23.309+00:00"`
+}
+
+type AcknowledgementsReport struct {
+	Acknowledged      []AcknowledgementResponse `json:"acknowledged"`
+	TotalAcknowledged int                       `json:"total_acknowledged"`
+	Pending           []models.User             `json:"pending"`
+	TotalPending      int                       `json:"total_pending"`
+	// The users of the courses and levels could not be resolved, pending
+	// only has the users that read the news
+	PendingPartial bool `json:"pending_partial"`
+}
+
+type RevisionResponse struct {
+	ID           string             `json:"_id" bson:"_id" example:"638660ca141aa4ee9faf07e8"`
+	News         string             `json:"news" bson:"news" example:"638660ca141aa4ee9faf07e8"`
+	Editor       models.User        `json:"editor" bson:"editor"`
+	Changes      []string           `json:"changes" bson:"changes" example:"title,body"`
+	Title        string             `json:"title" bson:"title" example:"Title !!"`
+	Headline     string             `json:"headline" bson:"headline" example:"Example..."`
+	Body         string             `json:"body,omitempty" bson:"body,omitempty" extensions:"x-omitempty" example:"This is a body..."`
+	Img          string             `json:"img" bson:"img" example:"638660ca141aa4ee9faf07e8"`
+	RestoredFrom string             `json:"restored_from,omitempty" bson:"restored_from,omitempty" extensions:"x-omitempty" example:"638660ca141aa4ee9faf07e8"`
+	Date         primitive.DateTime `json:"date" bson:"date" swaggertype:"string" example:"2022-09-21T20:10:23.309+00:00"`
+}
+
+type RevisionDiffResponse struct {
+	Field string `json:"field" example:"title"`
+	From  string `json:"from" example:"Title !!"`
+	To    string `json:"to" example:"New title !!"`
+}
+
+type MediaResponse struct {
+	ID        string             `json:"_id" bson:"_id" example:"638660ca141aa4ee9faf07e8"`
+	URL       string             `json:"url" bson:"url,omitempty" example:"https://repository.com/file/$dsK2!1"`
+	Thumbnail string             `json:"thumbnail" bson:"thumbnail,omitempty" example:"https://repository.com/file/$dsK2!2"`
+	Key       string             `json:"-" bson:"key"`
+	Width     int                `json:"width,omitempty" bson:"width,omitempty" extensions:"x-omitempty" example:"1920"`
+	Height    int                `json:"height,omitempty" bson:"height,omitempty" extensions:"x-omitempty" example:"1080"`
+	BlurHash  string             `json:"blurhash,omitempty" bson:"blurhash,omitempty" extensions:"x-omitempty" example:"LpDdPVBUwxX9m0WEjte=gJfjfQfj"`
+	Color     string             `json:"color,omitempty" bson:"color,omitempty" extensions:"x-omitempty" example:"#0816c8"`
+	Variants  []ImageVariant     `json:"-" bson:"variants"`
+	Uploader  *models.User       `json:"uploader,omitempty" bson:"uploader,omitempty" extensions:"x-omitempty"`
+	Uses      int                `json:"uses" bson:"refs" example:"2"`
+	Date      primitive.DateTime `json:"date" bson:"date" swaggertype:"string" example:"2022-09-21T20:10:23.309+00:00"`
 }
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"github.com/CPU-commits/Intranet_BNews/src/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var revisionsService *RevisionsService
+
+type RevisionsService struct{}
+
+func isRevisionField(field string) bool {
+	for _, revisionField := range models.REVISION_FIELDS {
+		if revisionField == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Store the content after the update, before is the news before it
+func (r *RevisionsService) saveRevision(
+	before *models.News,
+	update bson.D,
+	editorObjectId primitive.ObjectID,
+	restoredFrom primitive.ObjectID,
+) error {
+	after := *before
+	changes := []string{}
+	for _, field := range update {
+		switch field.Key {
+		case "update_date":
+			continue
+		case "title":
+			after.Title = field.Value.(string)
+		case "headline":
+			after.Headline = field.Value.(string)
+		case "body":
+			after.Body = field.Value.(string)
+		case "img":
+			after.Img = field.Value.(primitive.ObjectID)
+		}
+		changes = append(changes, field.Key)
+	}
+	// Content fields set with the same value did not change
+	revision := revisionsModel.NewModel(&after, editorObjectId, []string{}, primitive.NewDateTimeFromTime(time.Now()))
+	original := revisionsModel.NewModel(before, before.AuthorId, []string{}, before.UpdateDate)
+	for _, change := range changes {
+		if isRevisionField(change) && revision.Field(change) == original.Field(change) {
+			continue
+		}
+		revision.Changes = append(revision.Changes, change)
+	}
+	if len(revision.Changes) == 0 {
+		return nil
+	}
+	revision.RestoredFrom = restoredFrom
+	// News edited before the history keep their original content
+	total, err := revisionsModel.Use().CountDocuments(db.Ctx, bson.D{
+		{
+			Key:   "news",
+			Value: before.ID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if total == 0 {
+		if _, err := revisionsModel.Use().InsertOne(db.Ctx, original); err != nil {
+			return err
+		}
+	}
+	_, err = revisionsModel.Use().InsertOne(db.Ctx, revision)
+	return err
+}
+
+func (r *RevisionsService) getRevisions(match bson.M, withBody bool) ([]RevisionResponse, error) {
+	project := bson.M{
+		"news":          1,
+		"changes":       1,
+		"title":         1,
+		"headline":      1,
+		"img":           1,
+		"restored_from": 1,
+		"date":          1,
+		"editor": bson.M{
+			"$arrayElemAt": bson.A{"$editor", 0},
+		},
+	}
+	if withBody {
+		project["body"] = 1
+	}
+	cursor, err := revisionsModel.Use().Aggregate(db.Ctx, mongo.Pipeline{
+		bson.D{
+			{
+				Key:   "$match",
+				Value: match,
+			},
+		},
+		bson.D{
+			{
+				Key: "$sort",
+				Value: bson.D{
+					{Key: "date", Value: -1},
+					{Key: "_id", Value: -1},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$lookup",
+				Value: bson.M{
+					"from":         "users",
+					"localField":   "editor",
+					"foreignField": "_id",
+					"as":           "editor",
+					"pipeline": bson.A{
+						bson.M{
+							"$project": bson.M{
+								"name":            1,
+								"first_lastname":  1,
+								"second_lastname": 1,
+							},
+						},
+					},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key:   "$project",
+				Value: project,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	revisions := []RevisionResponse{}
+	if err = cursor.All(db.Ctx, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (r *RevisionsService) getRevision(idRevision string, claims *Claims) (*models.Revision, *models.News, *ErrorRes) {
+	revisionObjectId, err := primitive.ObjectIDFromHex(idRevision)
+	if err != nil {
+		return nil, nil, &ErrorRes{
+			Err:        fmt.Errorf("revisión no encontrada"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	var revision *models.Revision
+	err = revisionsModel.Use().FindOne(db.Ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: revisionObjectId,
+		},
+	}).Decode(&revision)
+	if err != nil {
+		return nil, nil, &ErrorRes{
+			Err:        fmt.Errorf("revisión no encontrada"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	newsData, errRes := newsService.getNewsToManage(revision.NewsID.Hex(), claims)
+	if errRes != nil {
+		return nil, nil, errRes
+	}
+	return revision, newsData, nil
+}
+
+func (r *RevisionsService) GetRevisions(idNews string, claims *Claims) ([]RevisionResponse, *ErrorRes) {
+	newsData, errRes := newsService.getNewsToManage(idNews, claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	revisions, err := r.getRevisions(bson.M{
+		"news": newsData.ID,
+	}, false)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return revisions, nil
+}
+
+func (r *RevisionsService) GetRevision(idRevision string, claims *Claims) (*RevisionResponse, *ErrorRes) {
+	revision, _, errRes := r.getRevision(idRevision, claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	revisions, err := r.getRevisions(bson.M{
+		"_id": revision.ID,
+	}, true)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	if len(revisions) == 0 {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("revisión no encontrada"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	return &revisions[0], nil
+}
+
+// Fields that differ from a revision to another of the same news
+func (r *RevisionsService) DiffRevisions(
+	from string,
+	to string,
+	claims *Claims,
+) ([]RevisionDiffResponse, *ErrorRes) {
+	fromRevision, _, errRes := r.getRevision(from, claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	toRevision, _, errRes := r.getRevision(to, claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	if fromRevision.NewsID != toRevision.NewsID {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("las revisiones no son de la misma noticia"),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	diff := []RevisionDiffResponse{}
+	for _, field := range models.REVISION_FIELDS {
+		if fromRevision.Field(field) == toRevision.Field(field) {
+			continue
+		}
+		diff = append(diff, RevisionDiffResponse{
+			Field: field,
+			From:  fromRevision.Field(field),
+			To:    toRevision.Field(field),
+		})
+	}
+	return diff, nil
+}
+
+// The content of the revision becomes a new revision
+func (r *RevisionsService) RestoreRevision(
+	idRevision string,
+	ifMatch string,
+	claims *Claims,
+) (*models.News, *ErrorRes) {
+	version, errRes := getIfMatchVersion(ifMatch)
+	if errRes != nil {
+		return nil, errRes
+	}
+	revision, newsData, errRes := r.getRevision(idRevision, claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	if version != 0 && newsData.Version != version {
+		return newsService.getConflict(newsData.ID)
+	}
+	update := bson.D{
+		{
+			Key:   "update_date",
+			Value: primitive.NewDateTimeFromTime(time.Now()),
+		},
+		{
+			Key:   "title",
+			Value: revision.Title,
+		},
+		{
+			Key:   "headline",
+			Value: revision.Headline,
+		},
+		{
+			Key:   "body",
+			Value: revision.Body,
+		},
+		{
+			Key:   "img",
+			Value: revision.Img,
+		},
+	}
+	return newsService.updateNews(newsData, update, claims, revision.ID, version)
+}
+
+func NewRevisionsService() *RevisionsService {
+	if revisionsService == nil {
+		revisionsService = &RevisionsService{}
+	}
+	return revisionsService
+}
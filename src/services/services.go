@@ -1,17 +1,27 @@
 package services
 
 import (
-	"github.com/CPU-commits/Intranet_BNews/src/aws_s3"
+	"github.com/CPU-commits/Intranet_BNews/src/cache"
 	"github.com/CPU-commits/Intranet_BNews/src/models"
 	"github.com/CPU-commits/Intranet_BNews/src/stack"
+	"github.com/CPU-commits/Intranet_BNews/src/storage"
 )
 
 // Models
 var newsModel = new(models.NewsModel)
-var likesModel = new(models.LikesModel)
+var reactionsModel = new(models.ReactionsModel)
+var commentsModel = new(models.CommentsModel)
+var categoriesModel = new(models.CategoriesModel)
+var readsModel = new(models.ReadsModel)
+var acknowledgementsModel = new(models.AcknowledgementsModel)
+var usersModel = new(models.UsersModel)
+var revisionsModel = new(models.RevisionsModel)
+var uploadsModel = new(models.UploadsModel)
+var mediaModel = new(models.MediaModel)
 
 var nats = stack.NewNats()
-var aws = aws_s3.NewAWSS3()
+var fileStorage = storage.NewStorage()
+var cacheStore = cache.NewCache()
 
 // Error Response
 type ErrorRes struct {
@@ -0,0 +1,418 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/db"
+	"github.com/CPU-commits/Intranet_BNews/src/forms"
+	"github.com/CPU-commits/Intranet_BNews/src/media"
+	"github.com/CPU-commits/Intranet_BNews/src/models"
+	"github.com/CPU-commits/Intranet_BNews/src/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Same limit of the images processed
+const MAX_UPLOAD_SIZE = media.MAX_FILE_SIZE
+
+const UPLOAD_URL_EXPIRY = 15 * time.Minute
+
+const UPLOADS_SWEEP_INTERVAL = time.Hour
+
+// Confirmed uploads are also looked for on this interval, in case a
+// confirmation was made by another instance
+const UPLOADS_PROCESS_INTERVAL = 30 * time.Second
+
+// Uploads taken by a worker are taken again after this time
+const UPLOADS_PROCESSING_TIMEOUT = 10 * time.Minute
+
+// Allowed content types of direct uploads and their extensions
+var UPLOAD_CONTENT_TYPES = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/webp": "webp",
+	"image/gif":  "gif",
+}
+
+var uploadsService *UploadsService
+
+type UploadsService struct {
+	confirmed chan struct{}
+}
+
+// Issue a presigned POST, the file goes from the client to the bucket
+func (u *UploadsService) GetUploadURL(
+	data forms.UploadURLDTO,
+	claims *Claims,
+) (*UploadURLResponse, *ErrorRes) {
+	ext, ok := UPLOAD_CONTENT_TYPES[data.ContentType]
+	if !ok {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("tipo de archivo no permitido"),
+			StatusCode: http.StatusUnsupportedMediaType,
+		}
+	}
+	if data.Size > MAX_UPLOAD_SIZE {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("el archivo supera el tamaño máximo de 20MB"),
+			StatusCode: http.StatusRequestEntityTooLarge,
+		}
+	}
+	userObjectID, err := primitive.ObjectIDFromHex(claims.ID)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	presigner, ok := fileStorage.(storage.PostPresigner)
+	if !ok {
+		return nil, &ErrorRes{
+			Err:        storage.ErrNotSupported,
+			StatusCode: http.StatusNotImplemented,
+		}
+	}
+	post, err := presigner.PresignPost(
+		storage.NewKey(ext),
+		data.ContentType,
+		data.Size,
+		UPLOAD_URL_EXPIRY,
+	)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	_, err = uploadsModel.Use().InsertOne(
+		db.Ctx,
+		uploadsModel.NewModel(post.Key, userObjectID, data.ContentType),
+	)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return &UploadURLResponse{
+		URL:       post.URL,
+		Key:       post.Key,
+		Fields:    post.Fields,
+		ExpiresAt: primitive.NewDateTimeFromTime(post.ExpiresAt),
+	}, nil
+}
+
+// Confirm a direct upload as image of the news. Only the metadata of the
+// object is checked here, the image is processed by a worker and set to
+// the news once it is stored. Returns the news as it is now
+func (u *UploadsService) ConfirmUpload(
+	data forms.ConfirmUploadDTO,
+	idNews string,
+	ifMatch string,
+	claims *Claims,
+) (*models.News, *ErrorRes) {
+	if !strings.HasPrefix(data.Key, "news/") {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("archivo no válido"),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	version, errRes := getIfMatchVersion(ifMatch)
+	if errRes != nil {
+		return nil, errRes
+	}
+	findNews, errRes := newsService.getNewsToManage(idNews, claims)
+	if errRes != nil {
+		return nil, errRes
+	}
+	if version != 0 && findNews.Version != version {
+		return newsService.getConflict(findNews.ID)
+	}
+	userObjectID, err := primitive.ObjectIDFromHex(claims.ID)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	// Uploads are confirmed once, by the user they were issued to
+	filter := bson.D{
+		{
+			Key:   "key",
+			Value: data.Key,
+		},
+		{
+			Key:   "user",
+			Value: userObjectID,
+		},
+		{
+			Key: "confirmed_date",
+			Value: bson.M{
+				"$exists": false,
+			},
+		},
+	}
+	var upload *models.Upload
+	err = uploadsModel.Use().FindOne(db.Ctx, filter).Decode(&upload)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("no existe la subida de archivo"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	info, err := fileStorage.Stat(upload.Key)
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("el archivo no fue subido"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	if info.Size > MAX_UPLOAD_SIZE || info.ContentType != upload.ContentType {
+		discardUpload(upload)
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("el archivo subido no es válido"),
+			StatusCode: http.StatusUnprocessableEntity,
+		}
+	}
+	result, err := uploadsModel.Use().UpdateOne(db.Ctx, filter, bson.D{
+		{
+			Key: "$set",
+			Value: bson.M{
+				"news":           findNews.ID,
+				"user_type":      claims.UserType,
+				"confirmed_date": primitive.NewDateTimeFromTime(time.Now()),
+			},
+		},
+	})
+	if err != nil {
+		return nil, &ErrorRes{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	if result.ModifiedCount == 0 {
+		return nil, &ErrorRes{
+			Err:        fmt.Errorf("no existe la subida de archivo"),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	u.notifyUploads()
+	return findNews, nil
+}
+
+// Wake the worker up, confirmed uploads do not wait for the next tick
+func (u *UploadsService) notifyUploads() {
+	select {
+	case u.confirmed <- struct{}{}:
+	default:
+	}
+}
+
+// Take a confirmed upload to process. Uploads taken by a worker that did
+// not finish are taken again after a while
+func (u *UploadsService) claimUpload() (*models.Upload, error) {
+	now := time.Now()
+	var upload *models.Upload
+	err := uploadsModel.Use().FindOneAndUpdate(
+		db.Ctx,
+		bson.M{
+			"confirmed_date": bson.M{
+				"$exists": true,
+			},
+			"$or": bson.A{
+				bson.M{
+					"processing_date": bson.M{
+						"$exists": false,
+					},
+				},
+				bson.M{
+					"processing_date": bson.M{
+						"$lte": primitive.NewDateTimeFromTime(now.Add(-UPLOADS_PROCESSING_TIMEOUT)),
+					},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$set",
+				Value: bson.M{
+					"processing_date": primitive.NewDateTimeFromTime(now),
+				},
+			},
+		},
+	).Decode(&upload)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return upload, err
+}
+
+// Store the image of a confirmed upload and set it to its news
+func (u *UploadsService) processUpload(upload *models.Upload) error {
+	claims := &Claims{
+		ID:       upload.UserID.Hex(),
+		UserType: upload.UserType,
+	}
+	// The news could be deleted or the user lose access meanwhile
+	findNews, errRes := newsService.getNewsToManage(upload.News.Hex(), claims)
+	if errRes != nil {
+		discardUpload(upload)
+		return errRes.Err
+	}
+	file, err := getUploadFile(upload.Key)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	fileDb, err := storeImage(file, upload.UserID)
+	if err != nil {
+		// Rejected images will not be valid on retry
+		if getImageErrorRes(err, http.StatusServiceUnavailable).StatusCode != http.StatusServiceUnavailable {
+			discardUpload(upload)
+		}
+		return err
+	}
+	discardUpload(upload)
+	defer releaseHeldImage(fileDb)
+	imgObjectId, err := primitive.ObjectIDFromHex(fileDb.ID.OID)
+	if err != nil {
+		return err
+	}
+	// The version was checked at confirmation
+	_, errRes = newsService.updateNews(findNews, bson.D{
+		{
+			Key:   "update_date",
+			Value: primitive.NewDateTimeFromTime(time.Now()),
+		},
+		{
+			Key:   "img",
+			Value: imgObjectId,
+		},
+	}, claims, primitive.NilObjectID, 0)
+	if errRes != nil {
+		return errRes.Err
+	}
+	return nil
+}
+
+func (u *UploadsService) processUploads() {
+	for {
+		upload, err := u.claimUpload()
+		if err != nil {
+			log.Printf("Error taking upload: %v\n", err)
+			return
+		}
+		if upload == nil {
+			return
+		}
+		if err := u.processUpload(upload); err != nil {
+			log.Printf("Error processing upload %s: %v\n", upload.Key, err)
+		}
+	}
+}
+
+// Process the confirmed uploads, out of the requests
+func (u *UploadsService) ProcessUploads() {
+	go func() {
+		ticker := time.NewTicker(UPLOADS_PROCESS_INTERVAL)
+		for {
+			select {
+			case <-ticker.C:
+			case <-u.confirmed:
+			}
+			u.processUploads()
+		}
+	}()
+}
+
+// The object is copied to a temporary file, images are read more than once
+func getUploadFile(key string) (*os.File, error) {
+	object, _, err := fileStorage.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+	file, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return nil, err
+	}
+	_, err = io.Copy(file, io.LimitReader(object, MAX_UPLOAD_SIZE))
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+	return file, nil
+}
+
+// Delete the uploaded object, then its record. If the object can not be
+// deleted the record is kept, so the sweep tries again
+func discardUpload(upload *models.Upload) error {
+	if err := fileStorage.Delete(upload.Key); err != nil {
+		return err
+	}
+	_, err := uploadsModel.Use().DeleteOne(db.Ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: upload.ID,
+		},
+	})
+	return err
+}
+
+// Objects of uploads never processed are deleted with their records
+func (u *UploadsService) sweepExpiredUploads() error {
+	cursor, err := uploadsModel.Use().Find(db.Ctx, bson.D{
+		{
+			Key: "date",
+			Value: bson.M{
+				"$lte": primitive.NewDateTimeFromTime(time.Now().Add(-models.UPLOADS_RETENTION)),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	var uploads []models.Upload
+	if err := cursor.All(db.Ctx, &uploads); err != nil {
+		return err
+	}
+	for i := range uploads {
+		if err := discardUpload(&uploads[i]); err != nil {
+			log.Printf("Error deleting upload %s: %v\n", uploads[i].Key, err)
+		}
+	}
+	return nil
+}
+
+// Delete the expired uploads
+func (u *UploadsService) SweepExpiredUploads() {
+	go func() {
+		ticker := time.NewTicker(UPLOADS_SWEEP_INTERVAL)
+		for range ticker.C {
+			if err := u.sweepExpiredUploads(); err != nil {
+				log.Printf("Error sweeping expired uploads: %v\n", err)
+			}
+		}
+	}()
+}
+
+func NewUploadsService() *UploadsService {
+	if uploadsService == nil {
+		uploadsService = &UploadsService{
+			confirmed: make(chan struct{}, 1),
+		}
+	}
+	return uploadsService
+}
@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/joho/godotenv"
@@ -25,6 +26,20 @@ type settings struct {
 	AWS_REGION          string
 	CLIENT_URL          string
 	NODE_ENV            string
+	REACTIONS           string
+	TRASH_DAYS          string
+	CACHE_DRIVER        string
+	CACHE_SIZE          string
+	REDIS_URL           string
+	IMAGE_URL_STRATEGY  string
+	IMAGE_URL_MINUTES   string
+	UPLOAD_PART_MB      string
+	UPLOAD_CONCURRENCY  string
+	STORAGE_DRIVER      string
+	STORAGE_ENDPOINT    string
+	STORAGE_PATH        string
+	STORAGE_URL         string
+	STORAGE_SECRET      string
 }
 
 func newSettings() *settings {
@@ -46,11 +61,30 @@ func newSettings() *settings {
 		AWS_REGION:          os.Getenv("AWS_REGION"),
 		CLIENT_URL:          os.Getenv("CLIENT_URL"),
 		NODE_ENV:            os.Getenv("NODE_ENV"),
+		REACTIONS:           os.Getenv("REACTIONS"),
+		TRASH_DAYS:          os.Getenv("TRASH_DAYS"),
+		CACHE_DRIVER:        os.Getenv("CACHE_DRIVER"),
+		CACHE_SIZE:          os.Getenv("CACHE_SIZE"),
+		REDIS_URL:           os.Getenv("REDIS_URL"),
+		IMAGE_URL_STRATEGY:  os.Getenv("IMAGE_URL_STRATEGY"),
+		IMAGE_URL_MINUTES:   os.Getenv("IMAGE_URL_MINUTES"),
+		UPLOAD_PART_MB:      os.Getenv("UPLOAD_PART_MB"),
+		UPLOAD_CONCURRENCY:  os.Getenv("UPLOAD_CONCURRENCY"),
+		STORAGE_DRIVER:      os.Getenv("STORAGE_DRIVER"),
+		STORAGE_ENDPOINT:    os.Getenv("STORAGE_ENDPOINT"),
+		STORAGE_PATH:        os.Getenv("STORAGE_PATH"),
+		STORAGE_URL:         os.Getenv("STORAGE_URL"),
+		STORAGE_SECRET:      os.Getenv("STORAGE_SECRET"),
 	}
 }
 
+// Test binaries run without .env
+func isTest() bool {
+	return strings.HasSuffix(os.Args[0], ".test")
+}
+
 func init() {
-	if os.Getenv("NODE_ENV") != "prod" {
+	if os.Getenv("NODE_ENV") != "prod" && !isTest() {
 		if err := godotenv.Load(); err != nil {
 			log.Fatalf("No .env file found")
 		}
@@ -1,12 +1,82 @@
 package smaps
 
-import "github.com/CPU-commits/Intranet_BNews/src/services"
+import (
+	"github.com/CPU-commits/Intranet_BNews/src/models"
+	"github.com/CPU-commits/Intranet_BNews/src/services"
+)
 
 type SingleNewsMap struct {
 	News *services.NewsResponse `json:"news"`
 }
 
 type NewsMap struct {
-	News  []services.NewsResponse `json:"news"`
-	Total int                     `json:"total" example:"15"`
+	News       []services.NewsResponse `json:"news"`
+	Total      int                     `json:"total" example:"15"`
+	NextCursor string                  `json:"next_cursor" example:"MTY2Mzc5MTAyMzMwOV82Mzg2NjBjYTE0MWFhNGVlOWZhZjA3ZTg"`
+}
+
+type ReactionsMap struct {
+	Reactions []services.ReactionResponse `json:"reactions"`
+}
+
+type CommentsMap struct {
+	Comments []services.CommentResponse `json:"comments"`
+	Total    int                        `json:"total" example:"15"`
+}
+
+type CommentIDMap struct {
+	ID string `json:"_id" example:"638660ca141aa4ee9faf07e8"`
+}
+
+type HiddenMap struct {
+	Hidden bool `json:"hidden"`
+}
+
+type CommentsDisabledMap struct {
+	CommentsDisabled bool `json:"comments_disabled"`
+}
+
+type CategoriesMap struct {
+	Categories []models.Category `json:"categories"`
+}
+
+type CategoryIDMap struct {
+	ID string `json:"_id" example:"638660ca141aa4ee9faf07e8"`
+}
+
+type TagsMap struct {
+	Tags []services.TagResponse `json:"tags"`
+}
+
+type UnreadCountMap struct {
+	Unread int `json:"unread" example:"3"`
+}
+
+type AcknowledgementsMap struct {
+	Acknowledged      []services.AcknowledgementResponse `json:"acknowledged"`
+	TotalAcknowledged int                                `json:"total_acknowledged" example:"20"`
+	Pending           []models.User                      `json:"pending"`
+	TotalPending      int                                `json:"total_pending" example:"15"`
+	PendingPartial    bool                               `json:"pending_partial" example:"false"`
+}
+
+type RevisionsMap struct {
+	Revisions []services.RevisionResponse `json:"revisions"`
+}
+
+type RevisionMap struct {
+	Revision services.RevisionResponse `json:"revision"`
+}
+
+type RevisionDiffMap struct {
+	Diff []services.RevisionDiffResponse `json:"diff"`
+}
+
+type UploadURLMap struct {
+	Upload services.UploadURLResponse `json:"upload"`
+}
+
+type MediaMap struct {
+	Media []services.MediaResponse `json:"media"`
+	Total int                      `json:"total" example:"15"`
 }
@@ -54,6 +54,11 @@ func (nats *NatsClient) Request(channel string, data []byte) (*nats.Msg, error)
 	return msg, err
 }
 
+// Request for the read path, where waiting the default timeout is not an option
+func (nats *NatsClient) RequestTimeout(channel string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+	return nats.conn.Request(channel, data, timeout)
+}
+
 func (client *NatsClient) PublishEncode(channel string, jsonData interface{}) error {
 	ec, err := nats.NewEncodedConn(client.conn, nats.JSON_ENCODER)
 	if err != nil {
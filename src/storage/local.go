@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/settings"
+)
+
+const DEFAULT_STORAGE_PATH = "files"
+
+// Route of the service that serves the presigned URLs
+const LOCAL_FILES_ROUTE = "/api/news/files"
+
+// Files in a folder of the server, presigned URLs point to this service
+type LocalStorage struct {
+	root   string
+	url    string
+	secret []byte
+}
+
+func (l *LocalStorage) getPath(key string) (string, error) {
+	cleanKey := path.Clean("/" + key)
+	if cleanKey == "/" || cleanKey != "/"+key {
+		return "", fmt.Errorf("archivo no válido")
+	}
+	return filepath.Join(l.root, filepath.FromSlash(cleanKey)), nil
+}
+
+func (l *LocalStorage) Put(key string, body io.Reader, contentType string) error {
+	filePath, err := l.getPath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return err
+	}
+	// Partial files are never visible with the key
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filePath)
+}
+
+func (l *LocalStorage) Get(key string) (io.ReadCloser, *FileInfo, error) {
+	info, err := l.Stat(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	filePath, _ := l.getPath(key)
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, info, nil
+}
+
+func (l *LocalStorage) Delete(key string) error {
+	filePath, err := l.getPath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (l *LocalStorage) sign(key string, expires string) string {
+	return hex.EncodeToString(hmacSHA256(l.secret, key+"\n"+expires))
+}
+
+func (l *LocalStorage) Presign(key string, expiry time.Duration) (string, error) {
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+	return fmt.Sprintf(
+		"%s%s/%s?expires=%s&signature=%s",
+		l.url,
+		LOCAL_FILES_ROUTE,
+		key,
+		expires,
+		l.sign(key, expires),
+	), nil
+}
+
+func (l *LocalStorage) Verify(key string, expires string, signature string) bool {
+	expiresUnix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresUnix {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(l.sign(key, expires)))
+}
+
+func (l *LocalStorage) Stat(key string) (*FileInfo, error) {
+	filePath, err := l.getPath(key)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, os.ErrNotExist
+	}
+	contentType := mime.TypeByExtension(filepath.Ext(filePath))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &FileInfo{
+		Size:        info.Size(),
+		ContentType: contentType,
+		ModTime:     info.ModTime(),
+	}, nil
+}
+
+// Files are signed with the STORAGE_SECRET env, it is required so the
+// URLs of the files do not share the secret of the sessions
+func newLocalStorage() *LocalStorage {
+	settingsData := settings.GetSettings()
+	if settingsData.STORAGE_SECRET == "" {
+		panic("STORAGE_SECRET is required by the local storage")
+	}
+	root := settingsData.STORAGE_PATH
+	if root == "" {
+		root = DEFAULT_STORAGE_PATH
+	}
+	return &LocalStorage{
+		root:   root,
+		url:    strings.TrimSuffix(settingsData.STORAGE_URL, "/"),
+		secret: []byte(settingsData.STORAGE_SECRET),
+	}
+}
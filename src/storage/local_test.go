@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLocalStorageGetPath(t *testing.T) {
+	local := &LocalStorage{root: "files"}
+	tests := []struct {
+		name string
+		key  string
+		want string
+		err  bool
+	}{
+		{"key", "news/a.jpg", filepath.Join("files", "news", "a.jpg"), false},
+		{"empty", "", "", true},
+		{"root", "/", "", true},
+		{"absolute", "/news/a.jpg", "", true},
+		{"parent", "../a.jpg", "", true},
+		{"parent inside", "news/../../a.jpg", "", true},
+		{"dot", "news/./a.jpg", "", true},
+		{"double slash", "news//a.jpg", "", true},
+		{"trailing slash", "news/", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := local.getPath(tt.key)
+			if (err != nil) != tt.err {
+				t.Fatalf("err = %v, want error %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalStorageVerify(t *testing.T) {
+	local := &LocalStorage{secret: []byte("secret")}
+	expires := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+	expired := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+	signature := local.sign("news/a.jpg", expires)
+	tests := []struct {
+		name      string
+		key       string
+		expires   string
+		signature string
+		want      bool
+	}{
+		{"valid", "news/a.jpg", expires, signature, true},
+		{"other key", "news/b.jpg", expires, signature, false},
+		{"other expiry", "news/a.jpg", expires + "0", signature, false},
+		{"expired", "news/a.jpg", expired, local.sign("news/a.jpg", expired), false},
+		{"bad expiry", "news/a.jpg", "never", local.sign("news/a.jpg", "never"), false},
+		{"empty signature", "news/a.jpg", expires, "", false},
+		{"other secret", "news/a.jpg", expires, (&LocalStorage{secret: []byte("other")}).sign("news/a.jpg", expires), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := local.Verify(tt.key, tt.expires, tt.signature); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/settings"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// Multipart uploads keep at most part size * concurrency bytes in memory,
+// overridable by the UPLOAD_PART_MB and UPLOAD_CONCURRENCY envs
+const (
+	DEFAULT_UPLOAD_PART_MB     = 5
+	DEFAULT_UPLOAD_CONCURRENCY = 2
+)
+
+// AWS S3 or any S3 compatible endpoint, like MinIO
+type S3Storage struct {
+	sess     *session.Session
+	svc      *s3.S3
+	uploader *s3manager.Uploader
+	bucket   string
+	region   string
+	postURL  string
+}
+
+func (s *S3Storage) Put(key string, body io.Reader, contentType string) error {
+	// Readers that can be read at any offset, like multipart files,
+	// are uploaded by parts without buffering them
+	_, err := s.uploader.Upload(&s3manager.UploadInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	})
+	return err
+}
+
+func (s *S3Storage) Get(key string) (io.ReadCloser, *FileInfo, error) {
+	output, err := s.svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return output.Body, &FileInfo{
+		Size:        aws.Int64Value(output.ContentLength),
+		ContentType: aws.StringValue(output.ContentType),
+		ModTime:     aws.TimeValue(output.LastModified),
+	}, nil
+}
+
+func (s *S3Storage) Delete(key string) error {
+	_, err := s.svc.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+func (s *S3Storage) Presign(key string, expiry time.Duration) (string, error) {
+	req, _ := s.svc.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	return req.Presign(expiry)
+}
+
+func (s *S3Storage) Stat(key string) (*FileInfo, error) {
+	output, err := s.svc.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &FileInfo{
+		Size:        aws.Int64Value(output.ContentLength),
+		ContentType: aws.StringValue(output.ContentType),
+		ModTime:     aws.TimeValue(output.LastModified),
+	}, nil
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// POST policy signed with Signature V4, the bucket rejects uploads with
+// another key, content type or a size out of range
+func (s *S3Storage) PresignPost(
+	key string,
+	contentType string,
+	maxSize int64,
+	expiry time.Duration,
+) (*PresignedPost, error) {
+	creds, err := s.sess.Config.Credentials.Get()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	date := now.Format("20060102")
+	credential := fmt.Sprintf(
+		"%s/%s/%s/s3/aws4_request",
+		creds.AccessKeyID,
+		date,
+		s.region,
+	)
+	fields := map[string]string{
+		"key":              key,
+		"Content-Type":     contentType,
+		"x-amz-algorithm":  "AWS4-HMAC-SHA256",
+		"x-amz-credential": credential,
+		"x-amz-date":       now.Format("20060102T150405Z"),
+	}
+	if creds.SessionToken != "" {
+		fields["x-amz-security-token"] = creds.SessionToken
+	}
+	conditions := []interface{}{
+		map[string]string{"bucket": s.bucket},
+		[]interface{}{"content-length-range", 1, maxSize},
+	}
+	for field, value := range fields {
+		conditions = append(conditions, map[string]string{field: value})
+	}
+	expiresAt := now.Add(expiry)
+	policy, err := json.Marshal(map[string]interface{}{
+		"expiration": expiresAt.Format("2006-01-02T15:04:05.000Z"),
+		"conditions": conditions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	fields["policy"] = base64.StdEncoding.EncodeToString(policy)
+	// Signing key
+	signingKey := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	fields["x-amz-signature"] = hex.EncodeToString(hmacSHA256(signingKey, fields["policy"]))
+	return &PresignedPost{
+		URL:       s.postURL,
+		Key:       key,
+		Fields:    fields,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func getUploadSettings() (int64, int) {
+	settingsData := settings.GetSettings()
+	partMB, err := strconv.Atoi(settingsData.UPLOAD_PART_MB)
+	// S3 does not accept parts smaller than 5MB
+	if err != nil || partMB < DEFAULT_UPLOAD_PART_MB {
+		partMB = DEFAULT_UPLOAD_PART_MB
+	}
+	concurrency, err := strconv.Atoi(settingsData.UPLOAD_CONCURRENCY)
+	if err != nil || concurrency <= 0 {
+		concurrency = DEFAULT_UPLOAD_CONCURRENCY
+	}
+	return int64(partMB) * 1024 * 1024, concurrency
+}
+
+// Without endpoint it connects to AWS, S3 compatible endpoints use
+// path-style addressing
+func newS3Storage(endpoint string) *S3Storage {
+	settingsData := settings.GetSettings()
+	config := &aws.Config{
+		Region: aws.String(settingsData.AWS_REGION),
+	}
+	postURL := fmt.Sprintf(
+		"https://%s.s3.%s.amazonaws.com",
+		settingsData.AWS_BUCKET,
+		settingsData.AWS_REGION,
+	)
+	if endpoint != "" {
+		config.Endpoint = aws.String(endpoint)
+		config.S3ForcePathStyle = aws.Bool(true)
+		postURL = fmt.Sprintf(
+			"%s/%s",
+			strings.TrimSuffix(endpoint, "/"),
+			settingsData.AWS_BUCKET,
+		)
+	}
+	sess := session.Must(session.NewSession(config))
+	partSize, concurrency := getUploadSettings()
+	uploader := s3manager.NewUploader(sess, func(u *s3manager.Uploader) {
+		u.PartSize = partSize
+		u.Concurrency = concurrency
+	})
+	return &S3Storage{
+		sess:     sess,
+		svc:      s3.New(sess),
+		uploader: uploader,
+		bucket:   settingsData.AWS_BUCKET,
+		region:   settingsData.AWS_REGION,
+		postURL:  postURL,
+	}
+}
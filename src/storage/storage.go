@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/CPU-commits/Intranet_BNews/src/settings"
+	"github.com/google/uuid"
+)
+
+// Backends, selected by the STORAGE_DRIVER env. S3 is the default
+const (
+	S3_DRIVER    = "s3"
+	MINIO_DRIVER = "minio"
+	LOCAL_DRIVER = "local"
+)
+
+var ErrNotSupported = fmt.Errorf("el almacenamiento no soporta esta operación")
+
+type FileInfo struct {
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+type Storage interface {
+	Put(key string, body io.Reader, contentType string) error
+	Get(key string) (io.ReadCloser, *FileInfo, error)
+	Delete(key string) error
+	Presign(key string, expiry time.Duration) (string, error)
+	Stat(key string) (*FileInfo, error)
+}
+
+// Backends that accept uploads straight from the client
+type PostPresigner interface {
+	PresignPost(key string, contentType string, maxSize int64, expiry time.Duration) (*PresignedPost, error)
+}
+
+// Backends that serve their presigned URLs through this service
+type URLVerifier interface {
+	Verify(key string, expires string, signature string) bool
+}
+
+// Form to upload a file directly to the storage
+type PresignedPost struct {
+	URL       string
+	Key       string
+	Fields    map[string]string
+	ExpiresAt time.Time
+}
+
+func NewKey(ext string) string {
+	return fmt.Sprintf("news/%s.%s", uuid.New().String(), ext)
+}
+
+func NewStorage() Storage {
+	settingsData := settings.GetSettings()
+	switch settingsData.STORAGE_DRIVER {
+	case LOCAL_DRIVER:
+		return newLocalStorage()
+	case MINIO_DRIVER:
+		return newS3Storage(settingsData.STORAGE_ENDPOINT)
+	default:
+		return newS3Storage("")
+	}
+}
@@ -0,0 +1,14 @@
+package utils
+
+import "strings"
+
+// Spreadsheets run cells starting with these as formulas
+const CSV_FORMULA_PREFIXES = "=+-@\t\r"
+
+// Cells written by users are exported as text
+func EscapeCSVCell(cell string) string {
+	if cell != "" && strings.ContainsRune(CSV_FORMULA_PREFIXES, rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
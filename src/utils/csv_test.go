@@ -0,0 +1,26 @@
+package utils
+
+import "testing"
+
+func TestEscapeCSVCell(t *testing.T) {
+	tests := []struct {
+		cell string
+		want string
+	}{
+		{"", ""},
+		{"Karen", "Karen"},
+		{"Rojas-Valdes", "Rojas-Valdes"},
+		{"2022-09-21 20:10:23", "2022-09-21 20:10:23"},
+		{"=HYPERLINK(\"http://x\")", "'=HYPERLINK(\"http://x\")"},
+		{"+1", "'+1"},
+		{"-1+2", "'-1+2"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"\r=1", "'\r=1"},
+	}
+	for _, tt := range tests {
+		if got := EscapeCSVCell(tt.cell); got != tt.want {
+			t.Errorf("EscapeCSVCell(%q) = %q, want %q", tt.cell, got, tt.want)
+		}
+	}
+}
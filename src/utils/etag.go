@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidIfMatch = fmt.Errorf("If-Match inválido")
+
+// If-Match uses the strong comparison, weak tags never match
+var ErrWeakIfMatch = fmt.Errorf("If-Match no admite ETags débiles")
+
+// Version required by an If-Match header, 0 if any version matches.
+// Tags are the version, optionally followed by a validator, "3" or "3-a1f0"
+func ParseIfMatchVersion(ifMatch string) (int, error) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return 0, nil
+	}
+	if strings.HasPrefix(ifMatch, "W/") {
+		return 0, ErrWeakIfMatch
+	}
+	if len(ifMatch) < 2 || !strings.HasPrefix(ifMatch, "\"") || !strings.HasSuffix(ifMatch, "\"") {
+		return 0, ErrInvalidIfMatch
+	}
+	etag := strings.SplitN(ifMatch[1:len(ifMatch)-1], "-", 2)[0]
+	version, err := strconv.Atoi(etag)
+	if err != nil || version <= 0 {
+		return 0, ErrInvalidIfMatch
+	}
+	return version, nil
+}
@@ -0,0 +1,37 @@
+package utils
+
+import "testing"
+
+func TestParseIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    int
+		err     error
+	}{
+		{"empty", "", 0, nil},
+		{"any", "*", 0, nil},
+		{"version", "\"3\"", 3, nil},
+		{"spaces", "  \"3\" ", 3, nil},
+		{"version and validator", "\"12-a1f0c3\"", 12, nil},
+		{"weak", "W/\"3\"", 0, ErrWeakIfMatch},
+		{"weak with validator", "W/\"3-a1f0c3\"", 0, ErrWeakIfMatch},
+		{"unquoted", "3", 0, ErrInvalidIfMatch},
+		{"unterminated", "\"3", 0, ErrInvalidIfMatch},
+		{"quote", "\"", 0, ErrInvalidIfMatch},
+		{"zero", "\"0\"", 0, ErrInvalidIfMatch},
+		{"negative", "\"-1\"", 0, ErrInvalidIfMatch},
+		{"not a version", "\"abc\"", 0, ErrInvalidIfMatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := ParseIfMatchVersion(tt.ifMatch)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if version != tt.want {
+				t.Errorf("version = %d, want %d", version, tt.want)
+			}
+		})
+	}
+}
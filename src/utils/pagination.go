@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Elements of a page. Every element costs lookups and signed URLs, so
+// pages are bounded
+const MAX_LIMIT = 100
+
+var ErrInvalidCursor = fmt.Errorf("cursor inválido")
+
+// A page has at least one element and at most MAX_LIMIT
+func ParseLimit(limit string) (int, error) {
+	limitNumber, err := strconv.Atoi(limit)
+	if err != nil || limitNumber < 1 || limitNumber > MAX_LIMIT {
+		return 0, fmt.Errorf("limit debe ser un número entre 1 y %d", MAX_LIMIT)
+	}
+	return limitNumber, nil
+}
+
+// Skip and limit of a page
+func ParseSkipLimit(skip string, limit string) (int, int, error) {
+	skipNumber, err := strconv.Atoi(skip)
+	if err != nil || skipNumber < 0 {
+		return 0, 0, fmt.Errorf("skip debe ser un número mayor o igual a 0")
+	}
+	limitNumber, err := ParseLimit(limit)
+	if err != nil {
+		return 0, 0, err
+	}
+	return skipNumber, limitNumber, nil
+}
+
+// Opaque position of an element in a listing sorted by date and _id
+func EncodeCursor(date primitive.DateTime, id string) string {
+	cursor := fmt.Sprintf("%d_%s", int64(date), id)
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor))
+}
+
+func DecodeCursor(cursor string) (primitive.DateTime, primitive.ObjectID, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, primitive.NilObjectID, ErrInvalidCursor
+	}
+	values := strings.Split(string(decoded), "_")
+	if len(values) != 2 {
+		return 0, primitive.NilObjectID, ErrInvalidCursor
+	}
+	date, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return 0, primitive.NilObjectID, ErrInvalidCursor
+	}
+	idObjectId, err := primitive.ObjectIDFromHex(values[1])
+	if err != nil {
+		return 0, primitive.NilObjectID, ErrInvalidCursor
+	}
+	return primitive.DateTime(date), idObjectId, nil
+}
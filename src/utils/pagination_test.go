@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestParseSkipLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		skip  string
+		limit string
+		want  [2]int
+		err   bool
+	}{
+		{"valid", "0", "15", [2]int{0, 15}, false},
+		{"skip", "30", "15", [2]int{30, 15}, false},
+		{"limit of one", "0", "1", [2]int{0, 1}, false},
+		{"max limit", "0", "100", [2]int{0, 100}, false},
+		{"limit over max", "0", "101", [2]int{}, true},
+		{"zero limit", "0", "0", [2]int{}, true},
+		{"negative limit", "0", "-5", [2]int{}, true},
+		{"negative skip", "-1", "15", [2]int{}, true},
+		{"not a number", "a", "15", [2]int{}, true},
+		{"empty limit", "0", "", [2]int{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skip, limit, err := ParseSkipLimit(tt.skip, tt.limit)
+			if (err != nil) != tt.err {
+				t.Fatalf("err = %v, want error %v", err, tt.err)
+			}
+			if err == nil && [2]int{skip, limit} != tt.want {
+				t.Errorf("got %d, %d, want %v", skip, limit, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+	id := primitive.NewObjectID()
+	date := primitive.DateTime(1663791023309)
+	decodedDate, decodedId, err := DecodeCursor(EncodeCursor(date, id.Hex()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decodedDate != date || decodedId != id {
+		t.Errorf("got %v %v, want %v %v", decodedDate, decodedId, date, id)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"empty", ""},
+		{"not base64", "%%%"},
+		{"no separator", base64.RawURLEncoding.EncodeToString([]byte("1663791023309"))},
+		{"bad date", base64.RawURLEncoding.EncodeToString([]byte("a_638660ca141aa4ee9faf07e8"))},
+		{"bad id", EncodeCursor(1663791023309, "nope")},
+		{"extra part", EncodeCursor(1663791023309, "638660ca141aa4ee9faf07e8_x")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := DecodeCursor(tt.cursor); err != ErrInvalidCursor {
+				t.Errorf("err = %v, want %v", err, ErrInvalidCursor)
+			}
+		})
+	}
+}